@@ -0,0 +1,118 @@
+package main
+
+import "bytes"
+
+// ordering is the order in which lines are sorted, merged and checked. It
+// follows GNU sort in the C locale: lines that are equal in the numeric or
+// case-insensitive order are compared byte by byte as a last resort, unless
+// only unique lines are wanted. Reversing reverses the whole order.
+type ordering struct {
+	numeric bool // compare leading numbers, -n
+	fold    bool // compare lower case letters as upper case, -f
+	reverse bool // reverse the result of comparisons, -r
+	unique  bool // lines that compare equal are duplicates, -u
+}
+
+// compare compares the specified lines, which do not contain newlines, and
+// returns a negative number, zero or a positive number.
+func (o ordering) compare(a, b []byte) int {
+	result := 0
+	switch {
+	case o.numeric:
+		result = compareNumeric(a, b)
+	case o.fold:
+		result = compareFold(a, b)
+	}
+	if result == 0 && !(o.unique && (o.numeric || o.fold)) {
+		result = bytes.Compare(a, b)
+	}
+	if o.reverse {
+		return -result
+	}
+	return result
+}
+
+// compareFold compares the specified lines byte by byte with the ASCII lower
+// case letters mapped to upper case.
+func compareFold(a, b []byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, y := toUpper(a[i]), toUpper(b[i])
+		if x != y {
+			return int(x) - int(y)
+		}
+	}
+	return len(a) - len(b)
+}
+
+// toUpper returns the upper case letter of an ASCII lower case letter and
+// all other bytes unchanged.
+func toUpper(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// compareNumeric compares the numbers at the start of the specified lines
+// like GNU sort -n: blanks are skipped, a minus sign is optional and the
+// number has decimal digits with an optional fraction after a point. There
+// are no limits on the number of digits. Lines that do not start with a
+// number compare like zero.
+func compareNumeric(a, b []byte) int {
+	aNegative, aInteger, aFraction := parseNumber(a)
+	bNegative, bInteger, bFraction := parseNumber(b)
+	aSign, bSign := numberSign(aNegative, aInteger, aFraction), numberSign(bNegative, bInteger, bFraction)
+	if aSign != bSign || aSign == 0 {
+		return aSign - bSign
+	}
+	result := len(aInteger) - len(bInteger)
+	if result == 0 {
+		result = bytes.Compare(aInteger, bInteger)
+	}
+	if result == 0 {
+		result = bytes.Compare(aFraction, bFraction)
+	}
+	return aSign * result
+}
+
+// parseNumber returns the sign, the integer digits without leading zeros and
+// the fraction digits without trailing zeros of the number at the start of
+// the specified line.
+func parseNumber(line []byte) (negative bool, integer []byte, fraction []byte) {
+	for len(line) > 0 && (line[0] == ' ' || line[0] == '\t') {
+		line = line[1:]
+	}
+	if len(line) > 0 && line[0] == '-' {
+		negative = true
+		line = line[1:]
+	}
+	end := digits(line)
+	integer = bytes.TrimLeft(line[:end], "0")
+	if end < len(line) && line[end] == '.' {
+		line = line[end+1:]
+		fraction = bytes.TrimRight(line[:digits(line)], "0")
+	}
+	return negative, integer, fraction
+}
+
+// digits returns the number of decimal digits at the start of the specified
+// line.
+func digits(line []byte) int {
+	i := 0
+	for i < len(line) && '0' <= line[i] && line[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// numberSign returns -1 for negative numbers, 0 for zero and 1 for positive
+// numbers.
+func numberSign(negative bool, integer []byte, fraction []byte) int {
+	switch {
+	case len(integer) == 0 && len(fraction) == 0:
+		return 0
+	case negative:
+		return -1
+	}
+	return 1
+}
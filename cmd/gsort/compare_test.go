@@ -0,0 +1,72 @@
+package main
+
+import "testing"
+
+// TestCompareNumeric tests the comparison of numbers like GNU sort -n.
+func TestCompareNumeric(t *testing.T) {
+	tests := map[string]struct {
+		a, b string
+		want int
+	}{
+		"equal":                {"42", "42", 0},
+		"less":                 {"9", "10", -1},
+		"leading_zeros":        {"007", "7", 0},
+		"leading_blanks":       {" \t42", "42", 0},
+		"negative":             {"-10", "-9", -1},
+		"negative_positive":    {"-1", "1", -1},
+		"negative_zero":        {"-0", "0", 0},
+		"fraction":             {"5.5", "5.50", 0},
+		"fraction_less":        {"5.05", "5.5", -1},
+		"fraction_only":        {".5", "0.5", 0},
+		"negative_fraction":    {"-.5", "-.25", -1},
+		"trailing_point":       {"5.", "5", 0},
+		"no_number":            {"abc", "0", 0},
+		"minus_only":           {"-", "", 0},
+		"plus_sign":            {"+5", "0", 0},
+		"text_after_number":    {"42abc", "42", 0},
+		"no_thousands":         {"1,000", "1", 0},
+		"no_exponent":          {"1e3", "1", 0},
+		"many_digits":          {"100000000000000000000000", "99999999999999999999999", 1},
+		"many_negative_digits": {"-100000000000000000000000", "-99999999999999999999999", -1},
+	}
+	for name, test := range tests {
+		if got := sign(compareNumeric([]byte(test.a), []byte(test.b))); got != test.want {
+			t.Errorf("%s: got %d but want %d", name, got, test.want)
+		}
+		if got := sign(compareNumeric([]byte(test.b), []byte(test.a))); got != -test.want {
+			t.Errorf("%s reversed: got %d but want %d", name, got, -test.want)
+		}
+	}
+}
+
+// TestOrdering tests the last-resort comparison and the reverse order.
+func TestOrdering(t *testing.T) {
+	tests := map[string]struct {
+		ordering ordering
+		a, b     string
+		want     int
+	}{
+		"bytes":                {ordering{}, "B", "a", -1},
+		"fold":                 {ordering{fold: true}, "a", "B", -1},
+		"fold_last_resort":     {ordering{fold: true}, "a", "A", 1},
+		"fold_unique":          {ordering{fold: true, unique: true}, "a", "A", 0},
+		"fold_underscore":      {ordering{fold: true}, "_", "a", 1},
+		"numeric_last_resort":  {ordering{numeric: true}, "07", "7", -1},
+		"numeric_unique":       {ordering{numeric: true, unique: true}, "07", "7", 0},
+		"numeric_before_fold":  {ordering{numeric: true, fold: true}, "2 a", "10 A", -1},
+		"reverse":              {ordering{reverse: true}, "a", "b", 1},
+		"reverse_last_resort":  {ordering{numeric: true, reverse: true}, "07", "7", 1},
+		"unique_without_keys":  {ordering{unique: true}, "a", "A", 1},
+		"equal_lines_reversed": {ordering{reverse: true}, "a", "a", 0},
+	}
+	for name, test := range tests {
+		if got := sign(test.ordering.compare([]byte(test.a), []byte(test.b))); got != test.want {
+			t.Errorf("%s: got %d but want %d", name, got, test.want)
+		}
+	}
+}
+
+// sign returns -1, 0 or 1 for negative numbers, zero and positive numbers.
+func sign(n int) int {
+	return min(max(n, -1), 1)
+}
@@ -0,0 +1,484 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"syscall"
+
+	"gitlab.com/dirk.krummacker/sorter/internal/extsort"
+	"gitlab.com/dirk.krummacker/sorter/internal/gsorter"
+	"gitlab.com/dirk.krummacker/sorter/internal/merge"
+)
+
+// The exit statuses are the same as those of GNU sort.
+const (
+	exitDisorder = 1 // the input is not sorted in check mode
+	exitFailure  = 2 // the command line is invalid or input or output failed
+)
+
+// algorithm is a sort function that can be selected on the command line.
+type algorithm struct {
+	name         string
+	sortFunction func(sort.Interface) // nil for the external merge sort
+}
+
+// algorithms is the registry of all sort functions that can be selected. The
+// in-memory sort functions break ties by the input order of the lines, so
+// they all give the same output. The external merge sort sorts chunks with a
+// stable sort, writes them to temporary files and merges them, like GNU
+// sort, so it needs little memory for large input.
+var algorithms = []algorithm{
+	{"bubble", gsorter.BubbleSort},
+	{"quick", gsorter.QuickSort},
+	{"goroutine", gsorter.GoroutineSort},
+	{"standard", sort.Sort},
+	{"stable", sort.Stable},
+	{"merge", nil},
+}
+
+// defaultAlgorithm is the name of the algorithm that is used if none is
+// selected.
+const defaultAlgorithm = "merge"
+
+// findAlgorithm returns the algorithm with the specified name.
+func findAlgorithm(name string) (algorithm, bool) {
+	for _, a := range algorithms {
+		if a.name == name {
+			return a, true
+		}
+	}
+	return algorithm{}, false
+}
+
+// algorithmNames returns the names of all algorithms.
+func algorithmNames() []string {
+	names := make([]string, len(algorithms))
+	for i, a := range algorithms {
+		names[i] = a.name
+	}
+	return names
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run sorts, merges or checks the input as the specified command line
+// arguments say and returns the exit status.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	o, err := parseArgs(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "gsort: %v\n", err)
+		if errors.As(err, new(usageError)) {
+			fmt.Fprintln(stderr, "Try 'gsort --help' for more information.")
+		}
+		return exitFailure
+	}
+	if o.help {
+		fmt.Fprintf(stdout, usage, strings.Join(algorithmNames(), ", "))
+		return 0
+	}
+	if o.parallel > 0 {
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(o.parallel))
+	}
+	if len(o.files) == 0 {
+		o.files = []string{"-"}
+	}
+	if o.check {
+		return check(o.files[0], o.ordering, stdin, stderr)
+	}
+
+	output := &outputFile{name: o.output, stdout: stdout}
+	a, _ := findAlgorithm(o.algorithm)
+	switch {
+	case o.merge:
+		err = mergeFiles(o.files, o.ordering, stdin, output)
+	case a.sortFunction == nil:
+		err = sortExternal(o.files, o.ordering, stdin, output)
+	default:
+		err = sortInMemory(o.files, o.ordering, a.sortFunction, stdin, output)
+	}
+	if err == nil {
+		// The output file is created even if there are no lines.
+		err = output.open()
+	}
+	if err = errors.Join(err, output.close()); err != nil {
+		fmt.Fprintf(stderr, "gsort: %v\n", err)
+		return exitFailure
+	}
+	return 0
+}
+
+// check reports the first line of the specified file that is out of order.
+// With unique lines, equal lines are out of order, too.
+func check(name string, o ordering, stdin io.Reader, stderr io.Writer) int {
+	input, err := openInput(name, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "gsort: %v\n", newFileError("open failed", name, err))
+		return exitFailure
+	}
+	defer input.Close()
+
+	lines := &lineReader{reader: bufio.NewReader(input)}
+	previous, _ := lines.next()
+	for number := 2; ; number++ {
+		line, ok := lines.next()
+		if !ok {
+			break
+		}
+		if result := o.compare(previous, line); result > 0 || o.unique && result == 0 {
+			fmt.Fprintf(stderr, "gsort: %s:%d: disorder: %s\n", name, number, line)
+			return exitDisorder
+		}
+		previous = line
+	}
+	if lines.err != nil {
+		fmt.Fprintf(stderr, "gsort: %v\n", readError(name, lines.err))
+		return exitFailure
+	}
+	return 0
+}
+
+// record is a line with its index in the input.
+type record struct {
+	line  []byte
+	index int
+}
+
+// recordSortable sorts records in an ordering and by their index if they
+// are equal in it.
+type recordSortable struct {
+	records  []record
+	ordering ordering
+}
+
+func (a recordSortable) Len() int { return len(a.records) }
+func (a recordSortable) Less(i, j int) bool {
+	if result := a.ordering.compare(a.records[i].line, a.records[j].line); result != 0 {
+		return result < 0
+	}
+	return a.records[i].index < a.records[j].index
+}
+func (a recordSortable) Swap(i, j int) { a.records[i], a.records[j] = a.records[j], a.records[i] }
+
+// sortInMemory reads all lines of the specified files, sorts them with the
+// specified sort function and writes them to the output.
+func sortInMemory(files []string, o ordering, sortFunction func(sort.Interface), stdin io.Reader, output io.Writer) error {
+	var records []record
+	for _, name := range files {
+		lines, err := readLines(name, stdin)
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			records = append(records, record{line, len(records)})
+		}
+	}
+	sortFunction(recordSortable{records, o})
+
+	i := 0
+	return writeLines(output, func() ([]byte, bool) {
+		if i == len(records) {
+			return nil, false
+		}
+		i++
+		return records[i-1].line, true
+	}, o)
+}
+
+// sortExternal sorts the lines of the specified files with an external merge
+// sort and writes them to the output.
+func sortExternal(files []string, o ordering, stdin io.Reader, output io.Writer) error {
+	readers := make([]io.Reader, len(files))
+	for i, name := range files {
+		input, err := openInput(name, stdin)
+		if err != nil {
+			return readError(name, err)
+		}
+		defer input.Close()
+		readers[i] = &terminatedReader{reader: input, name: name}
+	}
+
+	// The sorted lines are piped through writeLines to drop duplicates. Closing
+	// the reading end makes the sort stop if writing fails, and waiting for it
+	// makes sure that it has removed its temporary files before returning.
+	sorted, writer := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		options := extsort.Options{SortFunction: sort.Stable, Compare: o.compare}
+		writer.CloseWithError(extsort.Sort(io.MultiReader(readers...), writer, options))
+	}()
+	lines := &lineReader{reader: bufio.NewReader(sorted)}
+	err := writeLines(output, lines.next, o)
+	sorted.CloseWithError(err)
+	<-done
+	return errors.Join(err, lines.err)
+}
+
+// mergeFiles merges the lines of the specified sorted files and writes them
+// to the output. Files that are the same as the output file are read
+// completely before the output file is written.
+func mergeFiles(files []string, o ordering, stdin io.Reader, output *outputFile) error {
+	readers := make([]*lineReader, len(files))
+	pulls := make([]func() ([]byte, bool), len(files))
+	for i, name := range files {
+		var input io.Reader
+		if output.sameFile(name) {
+			data, err := os.ReadFile(name)
+			if err != nil {
+				return readError(name, err)
+			}
+			input = bytes.NewReader(data)
+		} else {
+			file, err := openInput(name, stdin)
+			if err != nil {
+				return readError(name, err)
+			}
+			defer file.Close()
+			input = file
+		}
+		readers[i] = &lineReader{reader: bufio.NewReader(input)}
+		pulls[i] = readers[i].next
+
+		// Like GNU sort, fail before writing anything if a file cannot be
+		// read at all, for example because it is a directory.
+		if _, err := readers[i].reader.Peek(1); err != nil && err != io.EOF {
+			return readError(name, err)
+		}
+	}
+
+	merger := merge.Merger[[]byte]{Comparator: o.compare}
+	if err := writeLines(output, merger.Pulls(pulls...), o); err != nil {
+		return err
+	}
+	for i, reader := range readers {
+		if reader.err != nil {
+			return readError(files[i], reader.err)
+		}
+	}
+	return nil
+}
+
+// writeLines writes the lines returned by the specified pull function to the
+// specified writer, each followed by a newline. With unique lines, only the
+// first line of every run of equal lines is written.
+func writeLines(w io.Writer, next func() ([]byte, bool), o ordering) error {
+	writer := bufio.NewWriter(w)
+	var previous []byte
+	written := false
+	for line, ok := next(); ok; line, ok = next() {
+		if o.unique && written && o.compare(previous, line) == 0 {
+			continue
+		}
+		writer.Write(line)
+		writer.WriteByte('\n')
+		previous, written = line, true
+	}
+	return writer.Flush()
+}
+
+// openInput opens the file with the specified name, or returns stdin for
+// "-".
+func openInput(name string, stdin io.Reader) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(stdin), nil
+	}
+	return os.Open(name)
+}
+
+// readLines returns the lines of the file with the specified name, or of
+// stdin for "-". The last line does not need to end with a newline.
+func readLines(name string, stdin io.Reader) ([][]byte, error) {
+	input, err := openInput(name, stdin)
+	if err != nil {
+		return nil, readError(name, err)
+	}
+	defer input.Close()
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, readError(name, err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return bytes.Split(bytes.TrimSuffix(data, []byte{'\n'}), []byte{'\n'}), nil
+}
+
+// fileError is a failed operation on a file. Its message has the format of
+// GNU sort, for example "read failed: /tmp: Is a directory".
+type fileError struct {
+	operation string // what failed, for example "open failed"
+	name      string // the name of the file as it is printed
+	err       error
+}
+
+// newFileError returns the error for the specified failed operation on the
+// file with the specified name. The name and the operation of a
+// *fs.PathError are replaced with the specified ones.
+func newFileError(operation string, name string, err error) error {
+	var pathError *fs.PathError
+	if errors.As(err, &pathError) {
+		err = pathError.Err
+	}
+	return &fileError{operation, name, err}
+}
+
+func (e *fileError) Error() string {
+	return e.operation + ": " + e.name + ": " + strerror(e.err)
+}
+
+func (e *fileError) Unwrap() error { return e.err }
+
+// readError returns the error for a failed open or read of the specified
+// input file. Like GNU sort, it reports failed opens as "cannot read".
+func readError(name string, err error) error {
+	var pathError *fs.PathError
+	if errors.As(err, &pathError) && pathError.Op == "open" {
+		return newFileError("cannot read", name, err)
+	}
+	return newFileError("read failed", name, err)
+}
+
+// strerror returns the message of the specified error. The messages of
+// system errors are those of the C library, which GNU sort prints, but Go
+// starts them with a lower case letter.
+func strerror(err error) string {
+	message := err.Error()
+	var errno syscall.Errno
+	if errors.As(err, &errno) && message != "" {
+		return strings.ToUpper(message[:1]) + message[1:]
+	}
+	return message
+}
+
+// lineReader returns the lines of a reader one by one. The last line does not
+// need to end with a newline.
+type lineReader struct {
+	reader *bufio.Reader
+	err    error // the first error other than io.EOF
+}
+
+// next returns the next line without the newline and true, or false at the
+// end of the input or after an error.
+func (r *lineReader) next() ([]byte, bool) {
+	line, err := r.reader.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		r.err = err
+		return nil, false
+	}
+	if len(line) == 0 {
+		return nil, false
+	}
+	return bytes.TrimSuffix(line, []byte{'\n'}), true
+}
+
+// terminatedReader adds a newline to the end of the input of a reader if it
+// does not end with one, so that the last line of a file is not joined with
+// the first line of the next file. Read errors are reported with the name of
+// the file.
+type terminatedReader struct {
+	reader       io.Reader
+	name         string
+	needsNewline bool
+}
+
+func (r *terminatedReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err != nil && err != io.EOF {
+		return n, readError(r.name, err)
+	}
+	if n > 0 {
+		r.needsNewline = p[n-1] != '\n'
+	}
+	if err == io.EOF && r.needsNewline {
+		if n == len(p) {
+			return n, nil
+		}
+		p[n] = '\n'
+		n++
+		r.needsNewline = false
+	}
+	return n, err
+}
+
+// outputFile writes to the file with the specified name, or to stdout if the
+// name is empty. The file is created when it is first written to, so that it
+// can also be an input file.
+type outputFile struct {
+	name   string
+	stdout io.Writer
+	file   *os.File
+}
+
+func (f *outputFile) Write(p []byte) (int, error) {
+	if err := f.open(); err != nil {
+		return 0, err
+	}
+	var n int
+	var err error
+	if f.file == nil {
+		n, err = f.stdout.Write(p)
+	} else {
+		n, err = f.file.Write(p)
+	}
+	if err != nil {
+		return n, newFileError("write failed", f.printedName(), err)
+	}
+	return n, nil
+}
+
+// printedName returns the name of the output file as GNU sort prints it.
+func (f *outputFile) printedName() string {
+	if f.name == "" {
+		return "'standard output'"
+	}
+	return f.name
+}
+
+// open creates the output file unless it is already open or stdout is
+// used.
+func (f *outputFile) open() error {
+	if f.name == "" || f.file != nil {
+		return nil
+	}
+	file, err := os.Create(f.name)
+	if err != nil {
+		return newFileError("open failed", f.name, err)
+	}
+	f.file = file
+	return nil
+}
+
+// close closes the output file if it was opened.
+func (f *outputFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+	if err := f.file.Close(); err != nil {
+		return newFileError("close failed", f.name, err)
+	}
+	return nil
+}
+
+// sameFile reports whether the output file exists and is the same as the
+// input file with the specified name.
+func (f *outputFile) sameFile(name string) bool {
+	if f.name == "" || name == "-" {
+		return false
+	}
+	outputInfo, err := os.Stat(f.name)
+	if err != nil {
+		return false
+	}
+	inputInfo, err := os.Stat(name)
+	return err == nil && os.SameFile(outputInfo, inputInfo)
+}
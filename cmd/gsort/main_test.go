@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// update makes TestGolden write the output of GNU sort to the golden files:
+//
+//	go test ./cmd/gsort -run Golden -update
+var update = flag.Bool("update", false, "update the golden files with the output of GNU sort")
+
+// goldenCases are the command lines that TestGolden compares with GNU sort.
+// The input of a case is read from the stdin file if it is not empty. The
+// status is the exit status of GNU sort.
+var goldenCases = []struct {
+	name   string
+	args   []string
+	stdin  string
+	status int
+}{
+	{"default", []string{"testdata/words.txt"}, "", 0},
+	{"reverse", []string{"-r", "testdata/words.txt"}, "", 0},
+	{"unique", []string{"-u", "testdata/words.txt"}, "", 0},
+	{"reverse_unique", []string{"-ru", "testdata/words.txt"}, "", 0},
+	{"fold", []string{"-f", "testdata/words.txt"}, "", 0},
+	{"fold_unique", []string{"-fu", "testdata/words.txt"}, "", 0},
+	{"fold_reverse_unique", []string{"-f", "-r", "-u", "testdata/words.txt"}, "", 0},
+	{"numeric", []string{"-n", "testdata/numbers.txt"}, "", 0},
+	{"numeric_unique", []string{"-nu", "testdata/numbers.txt"}, "", 0},
+	{"numeric_reverse", []string{"-n", "-r", "testdata/numbers.txt"}, "", 0},
+	{"numeric_reverse_unique", []string{"--numeric-sort", "--reverse", "--unique", "testdata/numbers.txt"}, "", 0},
+	{"numeric_fold", []string{"-fn", "testdata/words.txt"}, "", 0},
+	{"several_files", []string{"testdata/words.txt", "testdata/nonewline.txt", "testdata/empty.txt", "testdata/numbers.txt"}, "", 0},
+	{"stdin", []string{"-u", "-", "testdata/nonewline.txt"}, "testdata/words.txt", 0},
+	{"options_after_files", []string{"testdata/words.txt", "--rev", "--parallel", "2", "--", "testdata/nonewline.txt"}, "", 0},
+	{"empty", []string{"testdata/empty.txt"}, "", 0},
+	{"merge", []string{"-m", "testdata/sorted1.txt", "testdata/sorted2.txt"}, "", 0},
+	{"merge_unique", []string{"-mu", "testdata/sorted1.txt", "testdata/sorted2.txt", "testdata/sorted1.txt"}, "", 0},
+	{"merge_fold_unique", []string{"-mfu", "testdata/folded.txt", "testdata/folded.txt"}, "", 0},
+	{"check_sorted", []string{"-c", "testdata/sorted1.txt"}, "", 0},
+	{"check_disorder", []string{"-c", "testdata/words.txt"}, "", 1},
+	{"check_unique", []string{"-cu", "testdata/sorted1.txt"}, "", 1},
+	{"check_fold", []string{"--check", "-f", "testdata/folded.txt"}, "", 0},
+	{"check_numeric_reverse", []string{"-cnr", "testdata/numbers.txt"}, "", 1},
+	{"check_stdin", []string{"-c"}, "testdata/words.txt", 1},
+	{"check_with_output", []string{"-c", "-o", "out.txt", "testdata/words.txt"}, "", 2},
+	{"check_extra_operand", []string{"-c", "testdata/sorted1.txt", "testdata/sorted2.txt"}, "", 2},
+	{"missing_file", []string{"testdata/words.txt", "testdata/missing.txt"}, "", 2},
+	{"directory", []string{"testdata/words.txt", "testdata"}, "", 2},
+	{"merge_directory", []string{"-m", "testdata/sorted1.txt", "testdata"}, "", 2},
+	{"check_missing_file", []string{"-c", "testdata/missing.txt"}, "", 2},
+	{"check_directory", []string{"-c", "testdata"}, "", 2},
+	{"output_missing_directory", []string{"-o", "testdata/missing/out.txt", "testdata/words.txt"}, "", 2},
+	{"invalid_option", []string{"-rx", "testdata/words.txt"}, "", 2},
+	{"unrecognized_option", []string{"--foo", "testdata/words.txt"}, "", 2},
+	{"missing_argument", []string{"testdata/words.txt", "-o"}, "", 2},
+	{"parallel_zero", []string{"--parallel=0", "testdata/words.txt"}, "", 2},
+	{"parallel_invalid", []string{"--parallel=x", "testdata/words.txt"}, "", 2},
+}
+
+// TestGolden tests that the output of all algorithms is byte-identical to
+// the output of GNU sort in the C locale, which is stored in golden files.
+// The program name in messages is replaced with the name of this program.
+func TestGolden(t *testing.T) {
+	for _, test := range goldenCases {
+		stdoutFile := filepath.Join("testdata", "golden", test.name+".stdout")
+		stderrFile := filepath.Join("testdata", "golden", test.name+".stderr")
+		if *update {
+			updateGolden(t, test.args, test.stdin, test.status, stdoutFile, stderrFile)
+		}
+		wantStdout, err := os.ReadFile(stdoutFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantStderr, err := os.ReadFile(stderrFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			t.Fatal(err)
+		}
+
+		for _, name := range algorithmNames() {
+			var stdin []byte
+			if test.stdin != "" {
+				if stdin, err = os.ReadFile(test.stdin); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"--algorithm=" + name}, test.args...)
+			status := run(args, bytes.NewReader(stdin), &stdout, &stderr)
+			if status != test.status {
+				t.Errorf("%s with %s: got status %d but want %d", test.name, name, status, test.status)
+			}
+			if !bytes.Equal(stdout.Bytes(), wantStdout) {
+				t.Errorf("%s with %s: got output\n%s\nbut want\n%s", test.name, name, stdout.Bytes(), wantStdout)
+			}
+			if !bytes.Equal(stderr.Bytes(), wantStderr) {
+				t.Errorf("%s with %s: got messages\n%s\nbut want\n%s", test.name, name, stderr.Bytes(), wantStderr)
+			}
+		}
+	}
+}
+
+// programName matches the name of GNU sort in its messages.
+var programName = regexp.MustCompile(`(?m)^sort:|'sort --help'`)
+
+// updateGolden runs GNU sort with the specified arguments and writes its
+// output and messages to the specified golden files.
+func updateGolden(t *testing.T, args []string, stdinFile string, status int, stdoutFile string, stderrFile string) {
+	command := exec.Command("sort", args...)
+	command.Env = append(os.Environ(), "LC_ALL=C")
+	if stdinFile != "" {
+		stdin, err := os.Open(stdinFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer stdin.Close()
+		command.Stdin = stdin
+	}
+	var stdout, stderr bytes.Buffer
+	command.Stdout, command.Stderr = &stdout, &stderr
+	err := command.Run()
+	var exitError *exec.ExitError
+	if err != nil && !errors.As(err, &exitError) {
+		t.Fatal(err)
+	}
+	if got := command.ProcessState.ExitCode(); got != status {
+		t.Fatalf("%v: GNU sort exited with status %d instead of %d", args, got, status)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(stdoutFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stdoutFile, stdout.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	messages := programName.ReplaceAllStringFunc(stderr.String(), func(name string) string {
+		return strings.Replace(name, "sort", "gsort", 1)
+	})
+	if messages == "" {
+		err = os.Remove(stderrFile)
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+	} else {
+		err = os.WriteFile(stderrFile, []byte(messages), 0o644)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestOutputFile tests that the output file can also be an input file, when
+// sorting and when merging.
+func TestOutputFile(t *testing.T) {
+	tests := map[string]struct {
+		args   []string
+		golden string
+	}{
+		"sort":       {[]string{"-o", "out.txt", "out.txt"}, "default"},
+		"sort_merge": {[]string{"--algorithm=merge", "-oout.txt", "out.txt"}, "default"},
+		"sort_quick": {[]string{"--algorithm=quick", "--output=out.txt", "out.txt"}, "default"},
+		"merge":      {[]string{"-m", "-o", "out.txt", "sorted1.txt", "out.txt"}, "merge"},
+	}
+	for name, test := range tests {
+		dir := t.TempDir()
+		input := "testdata/words.txt"
+		if test.golden == "merge" {
+			input = "testdata/sorted2.txt"
+			copyFile(t, "testdata/sorted1.txt", filepath.Join(dir, "sorted1.txt"))
+		}
+		copyFile(t, input, filepath.Join(dir, "out.txt"))
+		args := make([]string, len(test.args))
+		for i, arg := range test.args {
+			args[i] = strings.ReplaceAll(arg, "out.txt", filepath.Join(dir, "out.txt"))
+			args[i] = strings.Replace(args[i], "sorted1.txt", filepath.Join(dir, "sorted1.txt"), 1)
+		}
+
+		var stdout, stderr bytes.Buffer
+		if status := run(args, nil, &stdout, &stderr); status != 0 {
+			t.Fatalf("%s: got status %d and %s", name, status, stderr.String())
+		}
+		got, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := os.ReadFile(filepath.Join("testdata", "golden", test.golden+".stdout"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stdout.Len() != 0 || !bytes.Equal(got, want) {
+			t.Errorf("%s: got output\n%s\nbut want\n%s", name, got, want)
+		}
+	}
+}
+
+// TestEmptyOutputFile tests that the output file is created even if there
+// is no input.
+func TestEmptyOutputFile(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "out.txt")
+	if status := run([]string{"-o", output}, strings.NewReader(""), nil, nil); status != 0 {
+		t.Fatalf("got status %d", status)
+	}
+	if info, err := os.Stat(output); err != nil || info.Size() != 0 {
+		t.Errorf("got %v, %v but want an empty file", info, err)
+	}
+}
+
+// TestMissingFile tests that a missing input file fails without creating the
+// output file.
+func TestMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "out.txt")
+	for _, name := range algorithmNames() {
+		var stderr bytes.Buffer
+		args := []string{"--algorithm", name, "-o", output, "testdata/words.txt", filepath.Join(dir, "missing.txt")}
+		if status := run(args, nil, nil, &stderr); status != exitFailure {
+			t.Errorf("%s: got status %d but want %d", name, status, exitFailure)
+		}
+		if want := "gsort: cannot read: " + filepath.Join(dir, "missing.txt") + ": No such file or directory\n"; stderr.String() != want {
+			t.Errorf("%s: got message %q but want %q", name, stderr.String(), want)
+		}
+		if _, err := os.Stat(output); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s: got output file", name)
+		}
+	}
+}
+
+// failingWriter is a writer whose writes fail like those to a full disk.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, syscall.ENOSPC
+}
+
+// TestWriteError tests that failed writes to stdout are reported like GNU
+// sort reports them.
+func TestWriteError(t *testing.T) {
+	for _, args := range [][]string{{"testdata/words.txt"}, {"-m", "testdata/sorted1.txt"}} {
+		for _, name := range algorithmNames() {
+			var stderr bytes.Buffer
+			status := run(append([]string{"--algorithm=" + name}, args...), nil, failingWriter{}, &stderr)
+			if status != exitFailure {
+				t.Errorf("%v with %s: got status %d but want %d", args, name, status, exitFailure)
+			}
+			if want := "gsort: write failed: 'standard output': No space left on device\n"; stderr.String() != want {
+				t.Errorf("%v with %s: got message %q but want %q", args, name, stderr.String(), want)
+			}
+		}
+	}
+}
+
+// TestSortExternalCleanup tests that the external merge sort has removed its
+// temporary files when sortExternal returns, even if writing fails.
+func TestSortExternalCleanup(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+	if err := sortExternal([]string{"testdata/words.txt"}, ordering{}, nil, failingWriter{}); err == nil {
+		t.Errorf("got no error")
+	}
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 0 {
+		t.Errorf("got %v, %v but want no temporary files", entries, err)
+	}
+}
+
+// copyFile copies the file with the specified name to the specified target.
+func copyFile(t *testing.T, name string, target string) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
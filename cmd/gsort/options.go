@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// options are the parsed command line arguments.
+type options struct {
+	ordering  ordering
+	algorithm string // the name of the sort algorithm, --algorithm
+	check     bool   // check whether the input is sorted, -c
+	merge     bool   // merge already sorted files, -m
+	output    string // the output file instead of stdout, -o
+	parallel  int    // the maximum number of threads, or 0, --parallel
+	help      bool   // print the usage, --help
+	files     []string
+}
+
+// usageError is a command line error after which the usage hint is printed.
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string { return e.message }
+
+// longOption is an option that can be given with a long name.
+type longOption struct {
+	name     string
+	short    byte // the short name, or 0 if there is none
+	argument bool // whether the option takes an argument
+}
+
+// longOptions are all options of this program in alphabetical order.
+var longOptions = []longOption{
+	{"algorithm", 0, true},
+	{"check", 'c', false},
+	{"help", 0, false},
+	{"ignore-case", 'f', false},
+	{"merge", 'm', false},
+	{"numeric-sort", 'n', false},
+	{"output", 'o', true},
+	{"parallel", 0, true},
+	{"reverse", 'r', false},
+	{"unique", 'u', false},
+}
+
+// usage is printed for --help.
+const usage = `Usage: gsort [OPTION]... [FILE]...
+Write the sorted concatenation of all FILEs to standard output.
+With no FILE, or when FILE is -, read standard input.
+
+  -c, --check             check whether the input is sorted; do not sort
+  -f, --ignore-case       fold lower case to upper case characters
+  -m, --merge             merge already sorted files; do not sort
+  -n, --numeric-sort      compare according to string numerical value
+  -o, --output=FILE       write result to FILE instead of standard output
+  -r, --reverse           reverse the result of comparisons
+  -u, --unique            output only the first of an equal run
+      --algorithm=NAME    sort with NAME: %s
+      --parallel=N        run at most N sorting threads at once
+      --help              display this help and exit
+
+The output is the same as that of GNU sort in the C locale.
+`
+
+// parseArgs parses the specified command line arguments without the program
+// name. Like GNU sort, it accepts short options grouped behind one dash,
+// long options abbreviated to a unique prefix, and options after the files.
+// A "--" ends the options.
+func parseArgs(args []string) (options, error) {
+	o := options{algorithm: defaultAlgorithm}
+	onlyFiles := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case onlyFiles || arg == "-" || !strings.HasPrefix(arg, "-"):
+			o.files = append(o.files, arg)
+
+		case arg == "--":
+			onlyFiles = true
+
+		case strings.HasPrefix(arg, "--"):
+			name, value, hasValue := strings.Cut(arg[2:], "=")
+			option, err := findLongOption(name, arg)
+			if err != nil {
+				return o, err
+			}
+			switch {
+			case option.argument && !hasValue:
+				if i+1 == len(args) {
+					return o, usageError{fmt.Sprintf("option '--%s' requires an argument", option.name)}
+				}
+				i++
+				value = args[i]
+			case !option.argument && hasValue:
+				return o, usageError{fmt.Sprintf("option '--%s' doesn't allow an argument", option.name)}
+			}
+			if err := o.set(option.name, value); err != nil {
+				return o, err
+			}
+
+		default:
+			for j := 1; j < len(arg); j++ {
+				option, ok := findShortOption(arg[j])
+				if !ok {
+					return o, usageError{fmt.Sprintf("invalid option -- '%c'", arg[j])}
+				}
+				value := ""
+				if option.argument {
+					// The argument is the rest of the group or the next one.
+					value = arg[j+1:]
+					if value == "" {
+						if i+1 == len(args) {
+							return o, usageError{fmt.Sprintf("option requires an argument -- '%c'", arg[j])}
+						}
+						i++
+						value = args[i]
+					}
+					j = len(arg)
+				}
+				if err := o.set(option.name, value); err != nil {
+					return o, err
+				}
+			}
+		}
+	}
+	return o, o.validate()
+}
+
+// findLongOption returns the option with the specified name or the only
+// option whose name starts with it.
+func findLongOption(name string, arg string) (longOption, error) {
+	var matches []longOption
+	for _, option := range longOptions {
+		if option.name == name {
+			return option, nil
+		}
+		if strings.HasPrefix(option.name, name) {
+			matches = append(matches, option)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return longOption{}, usageError{fmt.Sprintf("unrecognized option '%s'", arg)}
+	case 1:
+		return matches[0], nil
+	}
+	names := make([]string, len(matches))
+	for i, option := range matches {
+		names[i] = "'--" + option.name + "'"
+	}
+	return longOption{}, usageError{fmt.Sprintf("option '%s' is ambiguous; possibilities: %s", arg, strings.Join(names, " "))}
+}
+
+// findShortOption returns the option with the specified short name.
+func findShortOption(short byte) (longOption, bool) {
+	for _, option := range longOptions {
+		if option.short == short {
+			return option, true
+		}
+	}
+	return longOption{}, false
+}
+
+// set sets the option with the specified long name to the specified value.
+func (o *options) set(name string, value string) error {
+	switch name {
+	case "algorithm":
+		if _, ok := findAlgorithm(value); !ok {
+			return usageError{fmt.Sprintf("invalid argument '%s' for '--algorithm'\nValid arguments are: %s",
+				value, strings.Join(algorithmNames(), ", "))}
+		}
+		o.algorithm = value
+	case "check":
+		o.check = true
+	case "help":
+		o.help = true
+	case "ignore-case":
+		o.ordering.fold = true
+	case "merge":
+		o.merge = true
+	case "numeric-sort":
+		o.ordering.numeric = true
+	case "output":
+		o.output = value
+	case "parallel":
+		parallel, err := strconv.ParseUint(value, 10, 31)
+		if err != nil {
+			return fmt.Errorf("invalid --parallel argument '%s'", value)
+		}
+		if parallel == 0 {
+			return fmt.Errorf("number in parallel must be nonzero")
+		}
+		o.parallel = int(parallel)
+	case "reverse":
+		o.ordering.reverse = true
+	case "unique":
+		o.ordering.unique = true
+	}
+	return nil
+}
+
+// validate checks the combination of the options.
+func (o options) validate() error {
+	if !o.check || o.help {
+		return nil
+	}
+	if o.output != "" {
+		return fmt.Errorf("options '-co' are incompatible")
+	}
+	if len(o.files) > 1 {
+		return fmt.Errorf("extra operand '%s' not allowed with -c", o.files[1])
+	}
+	return nil
+}
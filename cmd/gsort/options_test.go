@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestParseArgs tests the parsing of valid command lines.
+func TestParseArgs(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		want options
+	}{
+		"no_args": {
+			args: []string{},
+			want: options{algorithm: defaultAlgorithm},
+		},
+		"grouped_short_options": {
+			args: []string{"-rnu", "a"},
+			want: options{ordering: ordering{numeric: true, reverse: true, unique: true}, algorithm: defaultAlgorithm, files: []string{"a"}},
+		},
+		"short_option_with_attached_argument": {
+			args: []string{"-fooutput", "a"},
+			want: options{ordering: ordering{fold: true}, algorithm: defaultAlgorithm, output: "output", files: []string{"a"}},
+		},
+		"short_option_with_separate_argument": {
+			args: []string{"-mo", "output", "-"},
+			want: options{algorithm: defaultAlgorithm, merge: true, output: "output", files: []string{"-"}},
+		},
+		"long_options": {
+			args: []string{"--algorithm=quick", "--output", "-r", "--parallel=4", "--merge"},
+			want: options{algorithm: "quick", merge: true, output: "-r", parallel: 4},
+		},
+		"abbreviated_long_options": {
+			args: []string{"--alg", "stable", "--ig", "--num"},
+			want: options{ordering: ordering{numeric: true, fold: true}, algorithm: "stable"},
+		},
+		"options_after_files": {
+			args: []string{"a", "-u", "b"},
+			want: options{ordering: ordering{unique: true}, algorithm: defaultAlgorithm, files: []string{"a", "b"}},
+		},
+		"end_of_options": {
+			args: []string{"-r", "--", "-u", "--", "-"},
+			want: options{ordering: ordering{reverse: true}, algorithm: defaultAlgorithm, files: []string{"-u", "--", "-"}},
+		},
+		"help": {
+			args: []string{"--help", "-c", "a", "b"},
+			want: options{algorithm: defaultAlgorithm, check: true, help: true, files: []string{"a", "b"}},
+		},
+	}
+	for name, test := range tests {
+		got, err := parseArgs(test.args)
+		if err != nil {
+			t.Errorf("%s: got error %v", name, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %+v but want %+v", name, got, test.want)
+		}
+	}
+}
+
+// TestParseArgsErrors tests the errors of invalid command lines and whether
+// the usage hint is printed for them.
+func TestParseArgsErrors(t *testing.T) {
+	tests := map[string]struct {
+		args  []string
+		want  string
+		usage bool
+	}{
+		"invalid_option":       {[]string{"-ux"}, "invalid option -- 'x'", true},
+		"unrecognized_option":  {[]string{"--unique-lines"}, "unrecognized option '--unique-lines'", true},
+		"missing_argument":     {[]string{"--output"}, "option '--output' requires an argument", true},
+		"unexpected_argument":  {[]string{"--reverse=yes"}, "option '--reverse' doesn't allow an argument", true},
+		"invalid_algorithm":    {[]string{"--algorithm=heap"}, "invalid argument 'heap' for '--algorithm'\nValid arguments are: bubble, quick, goroutine, standard, stable, merge", true},
+		"negative_parallel":    {[]string{"--parallel=-1"}, "invalid --parallel argument '-1'", false},
+		"check_with_output":    {[]string{"-co", "out"}, "options '-co' are incompatible", false},
+		"check_several_inputs": {[]string{"-c", "a", "b"}, "extra operand 'b' not allowed with -c", false},
+	}
+	for name, test := range tests {
+		_, err := parseArgs(test.args)
+		if test.want == "" {
+			if err != nil {
+				t.Errorf("%s: got error %v", name, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != test.want {
+			t.Errorf("%s: got error %v but want %s", name, err, test.want)
+		}
+		if usage := errors.As(err, new(usageError)); usage != test.usage {
+			t.Errorf("%s: got usage hint %v but want %v", name, usage, test.usage)
+		}
+	}
+}
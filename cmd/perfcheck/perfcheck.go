@@ -1,14 +1,51 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"reflect"
+	"os"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 
+	"gitlab.com/dirk.krummacker/sorter/internal/perf"
 	"gitlab.com/dirk.krummacker/sorter/internal/sorter"
 )
 
+// algorithm describes a sort function that is measured by this program.
+type algorithm struct {
+	name         string      // prefix of the column titles
+	sortFunction func([]int) // the function to measure
+	complexity   perf.Model  // the claimed average-case complexity
+	parallel     bool        // whether the function uses several goroutines
+}
+
+// algorithms is the registry of all sort functions that are measured, in the
+// order of the result table columns.
+var algorithms = []algorithm{
+	{"Bubble", sorter.BubbleSort, perf.Quadratic, false},
+	{"Quick", sorter.QuickSort, perf.Linearithmic, false},
+	{"Goroutine", sorter.GoroutineSort, perf.Linearithmic, true},
+	{"Standard", sort.Ints, perf.Linearithmic, false},
+}
+
+// partialStrategies are the strategies of the partial sort that are measured
+// in the top-k mode, in the order of the result table columns.
+var partialStrategies = []struct {
+	name     string
+	strategy sorter.PartialStrategy
+}{
+	{"Heap", sorter.HeapStrategy},
+	{"Quickselect", sorter.QuickselectStrategy},
+	{"Auto", sorter.AutoStrategy},
+}
+
+// minFitDuration is the minimum average duration in microseconds that a
+// measurement needs to be taken into account for complexity estimation.
+// Shorter measurements are dominated by noise.
+const minFitDuration = 20
+
 // Average returns the average of the specified ints or 0 if there are no
 // elements.
 func Average(input []int) int {
@@ -22,62 +59,319 @@ func Average(input []int) int {
 	return sum / len(input)
 }
 
-// Usage example: go run cmd/perfcheck/perfcheck.go
+// Usage example: go run cmd/perfcheck/perfcheck.go -budget 500ms
 func main() {
+	budget := flag.Duration("budget", 100*time.Millisecond,
+		"time budget for a single sort; slower algorithms are skipped at larger sizes")
+	var profileOptions perf.ProfileOptions
+	flag.StringVar(&profileOptions.CPUProfile, "cpuprofile", "", "write a CPU profile to this file")
+	flag.StringVar(&profileOptions.MemProfile, "memprofile", "", "write a memory profile to this file")
+	flag.StringVar(&profileOptions.Trace, "trace", "", "write an execution trace to this file")
+	profileAlgorithm := flag.String("profile-algorithm", "Quick", "the algorithm to profile")
+	profileSize := flag.Int("profile-size", 1000000, "the number of elements to profile with")
+	jsonFile := flag.String("json", "", "write the results as JSON to this file")
+	scalability := flag.Bool("scalability", false,
+		"measure the parallel algorithms under increasing GOMAXPROCS values")
+	topK := flag.Int("topk", 0, "measure partial sorts of the k smallest elements instead of full sorts")
+	flag.Parse()
+
 	sizes := []int{10, 50, 100, 500, 1000, 5000, 10000, 50000, 100000, 500000, 1000000}
 	loops := 10
 
+	// Profiling runs only the selected algorithm and size.
+	if profileOptions.Enabled() {
+		if err := runProfile(profileOptions, *profileAlgorithm, *profileSize, loops); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *scalability {
+		runScalability(sizes, loops)
+		return
+	}
+
+	if *topK > 0 {
+		runTopK(sizes, loops, *topK)
+		return
+	}
+
+	// Skips slow algorithms at larger sizes and collects the average
+	// durations per column for extrapolation and complexity estimation.
+	timeBudget := perf.NewBudget(*budget, minFitDuration)
+
+	// The machine-readable results, written if requested.
+	report := perf.Report{Tool: "perfcheck", Baseline: "Standard", Loops: loops}
+
+	// The rows of the memory table, printed after the duration table.
+	var memoryRows []string
+
 	fmt.Println()
 	fmt.Println("Elements |    Bubble/u     Bubble/s      Quick/u      Quick/s  Goroutine/u  Goroutine/s   Standard/u   Standard/s ")
 	fmt.Println("---------+--------------------------------------------------------------------------------------------------------")
 	for _, size := range sizes {
 		functionToDuration := make(map[string][]int)
+		functionToMemory := make(map[string][]perf.MemUsage)
 		for i := 0; i < loops; i++ {
 			original := sorter.CreateRandomInts(size)
-			for _, sortFunction := range sorter.SortFunctions {
-				name := runtime.FuncForPC(reflect.ValueOf(sortFunction).Pointer()).Name()
-
-				// Bubble sort is too slow on large lists.
-				if name == "gitlab.com/dirk.krummacker/sorter/internal/sorter.BubbleSort" && size >= 10000 {
+			for _, algorithm := range algorithms {
+				if timeBudget.Skip(algorithm.name) {
 					continue
 				}
 
 				data := make([]int, len(original))
 				copy(data, original)
 
-				unsortedName := name + ".unsorted"
-				unsortedDuration := runSortFunction(sortFunction, data)
+				unsortedName := algorithm.name + "/u"
+				unsortedDuration, unsortedMemory := runSortFunction(algorithm.sortFunction, data)
 				functionToDuration[unsortedName] = append(functionToDuration[unsortedName],
 					unsortedDuration)
+				functionToMemory[unsortedName] = append(functionToMemory[unsortedName],
+					unsortedMemory)
 
 				// Sort again the same data to discover if the sort algorithm
 				// can cope with that.
-				sortedName := name + ".sorted"
-				sortedDuration := runSortFunction(sortFunction, data)
+				sortedName := algorithm.name + "/s"
+				sortedDuration, sortedMemory := runSortFunction(algorithm.sortFunction, data)
 				functionToDuration[sortedName] = append(functionToDuration[sortedName],
 					sortedDuration)
+				functionToMemory[sortedName] = append(functionToMemory[sortedName],
+					sortedMemory)
+
+				// Slow algorithms would hang the run on large lists.
+				timeBudget.Charge(algorithm.name, unsortedDuration, sortedDuration)
+			}
+		}
+
+		fmt.Printf("%8d |", size)
+		for _, algorithm := range algorithms {
+			for i, column := range []string{algorithm.name + "/u", algorithm.name + "/s"} {
+				caseName := []string{perf.Unsorted, perf.Sorted}[i]
+				result := perf.Result{Algorithm: algorithm.name, Case: caseName, Size: size}
+				durations := functionToDuration[column]
+				if len(durations) == 0 {
+					estimate, ok := timeBudget.Extrapolate(column, size)
+					if !ok {
+						fmt.Printf("  %10s ", "timeout")
+						continue
+					}
+					fmt.Printf("  %10s ", fmt.Sprintf("~%d", int(estimate)))
+					result.Micros = estimate
+					result.Extrapolated = true
+					report.Results = append(report.Results, result)
+					continue
+				}
+				average := Average(durations)
+				fmt.Printf("  %10d ", average)
+				result.Micros = float64(average)
+				result.Memory = perf.AverageMemUsage(functionToMemory[column])
+				report.Results = append(report.Results, result)
+				timeBudget.Observe(column, size, float64(average))
+			}
+		}
+		fmt.Println()
+
+		memoryRow := fmt.Sprintf("%8d |", size)
+		for _, algorithm := range algorithms {
+			usages := append(functionToMemory[algorithm.name+"/u"], functionToMemory[algorithm.name+"/s"]...)
+			if len(usages) == 0 {
+				memoryRow += fmt.Sprintf("  %12s %12s %12s ", "-", "-", "-")
+				continue
+			}
+			memory := perf.AverageMemUsage(usages)
+			memoryRow += fmt.Sprintf("  %12.0f %12.1f %12.2f ", memory.Bytes, memory.Allocs, memory.GCs)
+		}
+		memoryRows = append(memoryRows, memoryRow)
+	}
+	fmt.Println()
+
+	printMemory(memoryRows)
+	printComplexities(timeBudget)
+
+	if *jsonFile != "" {
+		if err := perf.WriteReport(*jsonFile, report); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// printMemory prints the table with the average bytes allocated (B), the
+// average number of allocations (#) and the average number of garbage
+// collection cycles (GC) per sort.
+func printMemory(rows []string) {
+	header := "Elements |"
+	for _, algorithm := range algorithms {
+		header += fmt.Sprintf("  %12s %12s %12s ", algorithm.name+"/B", algorithm.name+"/#",
+			algorithm.name+"/GC")
+	}
+	fmt.Println(header)
+	fmt.Println("---------+" + strings.Repeat("-", len(header)-len("---------+")))
+	for _, row := range rows {
+		fmt.Println(row)
+	}
+	fmt.Println()
+}
+
+// printComplexities prints the best-fitting complexity class for every column
+// of the result table and warns if it does not match the claimed complexity.
+func printComplexities(timeBudget *perf.Budget) {
+	fmt.Println("Column       | Best fit     Constant (ns)  Claimed")
+	fmt.Println("-------------+-------------------------------------------")
+	for _, algorithm := range algorithms {
+		for _, column := range []string{algorithm.name + "/u", algorithm.name + "/s"} {
+			fit, ok := timeBudget.Fit(column)
+			if !ok {
+				fmt.Printf("%-12s | %-12s %13s  %s\n", column, "n/a", "n/a", algorithm.complexity.Name)
+				continue
+			}
+			fmt.Printf("%-12s | %-12s %13.4g  %s", column, fit.Model.Name,
+				fit.Constant*1000, algorithm.complexity.Name)
+			if fit.Model.Name != algorithm.complexity.Name {
+				fmt.Print("  WARNING: measured growth does not match")
+			}
+			fmt.Println()
+		}
+	}
+	fmt.Println()
+}
+
+// runScalability measures the parallel algorithms with GOMAXPROCS set to the
+// powers of two up to the number of CPUs and prints speedup, parallel
+// efficiency and the serial fraction according to Amdahl's law. It also shows
+// from which size on the parallel algorithms beat the quicksort algorithm.
+func runScalability(sizes []int, loops int) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	procCounts := perf.ProcCounts(runtime.NumCPU())
+	var reference algorithm
+	for _, algorithm := range algorithms {
+		if algorithm.name == "Quick" {
+			reference = algorithm
+		}
+	}
+
+	for _, algorithm := range algorithms {
+		if !algorithm.parallel {
+			continue
+		}
+		payOffSize := 0
+		fmt.Println()
+		fmt.Printf("Elements | Procs  %10s      Quick  Speedup  Efficiency  Serial fraction\n",
+			algorithm.name)
+		fmt.Println("---------+-------------------------------------------------------------------")
+		for _, size := range sizes {
+			durations := make([][]int, len(procCounts))
+			var referenceDurations []int
+			for i := 0; i < loops; i++ {
+				original := sorter.CreateRandomInts(size)
+				data := make([]int, len(original))
+				for j, procs := range procCounts {
+					runtime.GOMAXPROCS(procs)
+					copy(data, original)
+					duration, _ := runSortFunction(algorithm.sortFunction, data)
+					durations[j] = append(durations[j], duration)
+				}
+				copy(data, original)
+				duration, _ := runSortFunction(reference.sortFunction, data)
+				referenceDurations = append(referenceDurations, duration)
+			}
+
+			serial := float64(Average(durations[0]))
+			referenceAverage := Average(referenceDurations)
+			for j, procs := range procCounts {
+				average := Average(durations[j])
+				speedup := perf.Speedup(serial, float64(average))
+				fraction := "-"
+				if f, ok := perf.SerialFraction(speedup, procs); ok {
+					fraction = fmt.Sprintf("%.3f", f)
+				}
+				label := ""
+				if j == len(procCounts)-1 && payOffSize == 0 && average > 0 && average < referenceAverage {
+					payOffSize = size
+					label = "  <- parallelism pays off"
+				}
+				fmt.Printf("%8d | %5d  %10d %10d  %7.2f  %10.2f  %15s%s\n", size, procs, average,
+					referenceAverage, speedup, perf.Efficiency(speedup, procs), fraction, label)
 			}
 		}
+		fmt.Println()
+		if payOffSize == 0 {
+			fmt.Printf("%s is not faster than Quick at any size.\n", algorithm.name)
+		} else {
+			fmt.Printf("%s is faster than Quick from %d elements on with %d procs.\n",
+				algorithm.name, payOffSize, procCounts[len(procCounts)-1])
+		}
+	}
+	fmt.Println()
+}
 
-		fmt.Printf("%8d |  %10d   %10d   %10d   %10d   %10d   %10d   %10d   %10d",
-			size,
-			Average(functionToDuration["gitlab.com/dirk.krummacker/sorter/internal/sorter.BubbleSort.unsorted"]),
-			Average(functionToDuration["gitlab.com/dirk.krummacker/sorter/internal/sorter.BubbleSort.sorted"]),
-			Average(functionToDuration["gitlab.com/dirk.krummacker/sorter/internal/sorter.QuickSort.unsorted"]),
-			Average(functionToDuration["gitlab.com/dirk.krummacker/sorter/internal/sorter.QuickSort.sorted"]),
-			Average(functionToDuration["gitlab.com/dirk.krummacker/sorter/internal/sorter.GoroutineSort.unsorted"]),
-			Average(functionToDuration["gitlab.com/dirk.krummacker/sorter/internal/sorter.GoroutineSort.sorted"]),
-			Average(functionToDuration["sort.Ints.unsorted"]),
-			Average(functionToDuration["sort.Ints.sorted"]))
+// runTopK measures the partial sort of the k smallest elements with every
+// strategy and compares it with a full sort by the quicksort algorithm.
+func runTopK(sizes []int, loops int, k int) {
+	fmt.Println()
+	fmt.Printf("Partial sort of the %d smallest elements\n", k)
+	fmt.Println()
+	header := "Elements |"
+	for _, partialStrategy := range partialStrategies {
+		header += fmt.Sprintf("  %12s ", partialStrategy.name)
+	}
+	header += fmt.Sprintf("  %12s ", "Quick")
+	fmt.Println(header)
+	fmt.Println("---------+" + strings.Repeat("-", len(header)-len("---------+")))
+	for _, size := range sizes {
+		durations := make([][]int, len(partialStrategies)+1)
+		for i := 0; i < loops; i++ {
+			original := sorter.CreateRandomInts(size)
+			data := make([]int, size)
+			for j, partialStrategy := range partialStrategies {
+				partialSorter := sorter.PartialSorter{Strategy: partialStrategy.strategy}
+				copy(data, original)
+				duration, _ := runSortFunction(func(data []int) { partialSorter.PartialSort(data, k) }, data)
+				durations[j] = append(durations[j], duration)
+			}
+			copy(data, original)
+			duration, _ := runSortFunction(sorter.QuickSort, data)
+			durations[len(partialStrategies)] = append(durations[len(partialStrategies)], duration)
+		}
+		fmt.Printf("%8d |", size)
+		for _, column := range durations {
+			fmt.Printf("  %12d ", Average(column))
+		}
 		fmt.Println()
 	}
 	fmt.Println()
 }
 
+// runProfile executes the algorithm with the specified name repeatedly on
+// random data of the specified size while recording the profiles selected by
+// the specified options.
+func runProfile(options perf.ProfileOptions, name string, size int, loops int) error {
+	for _, algorithm := range algorithms {
+		if algorithm.name != name {
+			continue
+		}
+		originals := make([][]int, loops)
+		for i := range originals {
+			originals[i] = sorter.CreateRandomInts(size)
+		}
+		return perf.Profile(options, func() {
+			for _, data := range originals {
+				runSortFunction(algorithm.sortFunction, data)
+			}
+		})
+	}
+	return fmt.Errorf("unknown algorithm %q", name)
+}
+
 // runSortFunction executes the specified sort function on the specified data
-// and returns the microseconds used.
-func runSortFunction(sortFunction func([]int), data []int) int {
-	before := time.Now().UnixMicro()
-	sortFunction(data)
-	return int(time.Now().UnixMicro() - before)
+// and returns the microseconds used and the memory allocated.
+func runSortFunction(sortFunction func([]int), data []int) (int, perf.MemUsage) {
+	var duration int
+	memory := perf.MeasureMemory(func() {
+		before := time.Now().UnixMicro()
+		sortFunction(data)
+		duration = int(time.Now().UnixMicro() - before)
+	})
+	return duration, memory
 }
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"gitlab.com/dirk.krummacker/sorter/internal/perf"
+)
+
+// The dimensions of a chart in pixels.
+const (
+	chartWidth   = 640
+	chartHeight  = 400
+	marginLeft   = 70
+	marginRight  = 130
+	marginTop    = 30
+	marginBottom = 50
+)
+
+// colors is the palette used for the series of a chart.
+var colors = []string{"#1f77b4", "#d62728", "#2ca02c", "#ff7f0e", "#9467bd", "#8c564b", "#e377c2"}
+
+// point is a single data point of a chart.
+type point struct {
+	x, y float64
+}
+
+// series is a named line in a chart. Extrapolated points are drawn dashed.
+type series struct {
+	name         string
+	points       []point
+	extrapolated []point
+}
+
+// section is the part of the report for one measured case.
+type section struct {
+	Case       string
+	TimeChart  template.HTML
+	SpeedChart template.HTML
+	Sizes      []int
+	Rows       []row
+}
+
+// row is a line of the result table of a section.
+type row struct {
+	Algorithm string
+	Cells     []string
+}
+
+// page is the data that the report template is executed with.
+type page struct {
+	Tool     string
+	Baseline string
+	Loops    int
+	Sections []section
+}
+
+// reportTemplate is the self-contained HTML page of the report.
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Sort benchmark report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.2em 0.6em; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+svg { margin: 0 1em 1em 0; }
+</style>
+</head>
+<body>
+<h1>Sort benchmark report</h1>
+<p>Created by {{.Tool}} with {{.Loops}} measurements per value. Durations are
+in microseconds; values marked with ~ are extrapolated. Speedups are relative
+to {{.Baseline}}.</p>
+{{range .Sections}}
+<h2>Case: {{.Case}}</h2>
+{{.TimeChart}}
+{{.SpeedChart}}
+<table>
+<tr><th>Algorithm</th>{{range .Sizes}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr><td>{{.Algorithm}}</td>{{range .Cells}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+// Usage example: go run cmd/perfreport/main.go -in results.json -out report.html
+func main() {
+	in := flag.String("in", "", "the JSON results of perfcheck or perftest; standard input if empty")
+	out := flag.String("out", "", "the HTML file to write; standard output if empty")
+	flag.Parse()
+
+	if err := run(*in, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run reads the report from the file named in and writes the HTML report to
+// the file named out. Empty names stand for standard input and output. Errors
+// from closing the files are returned, too, because a failed close of the
+// output file can mean that the report was not written completely.
+func run(in string, out string) (err error) {
+	var reader io.Reader = os.Stdin
+	if in != "" {
+		var file *os.File
+		if file, err = os.Open(in); err != nil {
+			return err
+		}
+		defer func() { err = errors.Join(err, file.Close()) }()
+		reader = file
+	}
+	report, err := perf.ReadReport(reader)
+	if err != nil {
+		return err
+	}
+
+	var writer io.Writer = os.Stdout
+	if out != "" {
+		var file *os.File
+		if file, err = os.Create(out); err != nil {
+			return err
+		}
+		defer func() { err = errors.Join(err, file.Close()) }()
+		writer = file
+	}
+	return WriteHTML(writer, report)
+}
+
+// WriteHTML renders the specified report as a self-contained HTML page with
+// inline SVG charts.
+func WriteHTML(writer io.Writer, report perf.Report) error {
+	p := page{Tool: report.Tool, Baseline: report.Baseline, Loops: report.Loops}
+	for _, caseName := range []string{perf.Unsorted, perf.Sorted} {
+		results := filterCase(report.Results, caseName)
+		if len(results) == 0 {
+			continue
+		}
+		p.Sections = append(p.Sections, section{
+			Case:       caseName,
+			TimeChart:  renderChart("Time vs. size ("+caseName+")", "microseconds", timeSeries(results)),
+			SpeedChart: renderChart("Speedup vs. "+report.Baseline+" ("+caseName+")", "speedup", Speedups(results, report.Baseline)),
+			Sizes:      sizesOf(results),
+			Rows:       tableRows(results),
+		})
+	}
+	return reportTemplate.Execute(writer, p)
+}
+
+// filterCase returns the results of the specified case.
+func filterCase(results []perf.Result, caseName string) []perf.Result {
+	var filtered []perf.Result
+	for _, result := range results {
+		if result.Case == caseName {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+// algorithmsOf returns the names of the algorithms of the specified results
+// in the order of their first appearance.
+func algorithmsOf(results []perf.Result) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, result := range results {
+		if !seen[result.Algorithm] {
+			seen[result.Algorithm] = true
+			names = append(names, result.Algorithm)
+		}
+	}
+	return names
+}
+
+// sizesOf returns the distinct sizes of the specified results in ascending
+// order.
+func sizesOf(results []perf.Result) []int {
+	var sizes []int
+	seen := make(map[int]bool)
+	for _, result := range results {
+		if !seen[result.Size] {
+			seen[result.Size] = true
+			sizes = append(sizes, result.Size)
+		}
+	}
+	sort.Ints(sizes)
+	return sizes
+}
+
+// timeSeries returns one series of durations per algorithm.
+func timeSeries(results []perf.Result) []series {
+	var all []series
+	for _, name := range algorithmsOf(results) {
+		s := series{name: name}
+		for _, result := range results {
+			if result.Algorithm != name {
+				continue
+			}
+			p := point{float64(result.Size), result.Micros}
+			if result.Extrapolated {
+				s.extrapolated = append(s.extrapolated, p)
+			} else {
+				s.points = append(s.points, p)
+			}
+		}
+		all = append(all, s)
+	}
+	return all
+}
+
+// Speedups returns one series per algorithm other than the baseline with the
+// ratio of the baseline duration to the algorithm duration per size. Only
+// measured, non-zero durations are compared.
+func Speedups(results []perf.Result, baseline string) []series {
+	baselineMicros := make(map[int]float64)
+	for _, result := range results {
+		if result.Algorithm == baseline && !result.Extrapolated && result.Micros > 0 {
+			baselineMicros[result.Size] = result.Micros
+		}
+	}
+	var all []series
+	for _, name := range algorithmsOf(results) {
+		if name == baseline {
+			continue
+		}
+		s := series{name: name}
+		for _, result := range results {
+			reference, ok := baselineMicros[result.Size]
+			if result.Algorithm != name || !ok || result.Extrapolated || result.Micros <= 0 {
+				continue
+			}
+			s.points = append(s.points, point{float64(result.Size), reference / result.Micros})
+		}
+		all = append(all, s)
+	}
+	return all
+}
+
+// tableRows returns the rows of the result table, one per algorithm.
+func tableRows(results []perf.Result) []row {
+	sizes := sizesOf(results)
+	var rows []row
+	for _, name := range algorithmsOf(results) {
+		r := row{Algorithm: name, Cells: make([]string, len(sizes))}
+		for i, size := range sizes {
+			r.Cells[i] = "-"
+			for _, result := range results {
+				if result.Algorithm != name || result.Size != size {
+					continue
+				}
+				r.Cells[i] = fmt.Sprintf("%.0f", result.Micros)
+				if result.Extrapolated {
+					r.Cells[i] = "~" + r.Cells[i]
+				}
+			}
+		}
+		rows = append(rows, r)
+	}
+	return rows
+}
+
+// decades returns the powers of ten that enclose the positive values of the
+// specified points on one axis.
+func decades(all []series, value func(point) float64) (int, int) {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, s := range all {
+		for _, p := range append(append([]point{}, s.points...), s.extrapolated...) {
+			if v := value(p); v > 0 {
+				low = math.Min(low, math.Log10(v))
+				high = math.Max(high, math.Log10(v))
+			}
+		}
+	}
+	if math.IsInf(low, 1) {
+		return 0, 1
+	}
+	from, to := int(math.Floor(low)), int(math.Ceil(high))
+	if from == to {
+		to++
+	}
+	return from, to
+}
+
+// renderChart returns an inline SVG log-log chart of the specified series.
+// Points with non-positive coordinates cannot be shown and are skipped.
+func renderChart(title string, yLabel string, all []series) template.HTML {
+	xFrom, xTo := decades(all, func(p point) float64 { return p.x })
+	yFrom, yTo := decades(all, func(p point) float64 { return p.y })
+	plotWidth := float64(chartWidth - marginLeft - marginRight)
+	plotHeight := float64(chartHeight - marginTop - marginBottom)
+	xPos := func(x float64) float64 {
+		return marginLeft + (math.Log10(x)-float64(xFrom))/float64(xTo-xFrom)*plotWidth
+	}
+	yPos := func(y float64) float64 {
+		return marginTop + plotHeight - (math.Log10(y)-float64(yFrom))/float64(yTo-yFrom)*plotHeight
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-size="12">`,
+		chartWidth, chartHeight)
+	fmt.Fprintf(&b, `<text x="%d" y="18" font-weight="bold">%s</text>`,
+		marginLeft, template.HTMLEscapeString(title))
+
+	// Grid lines and labels at every power of ten.
+	for e := xFrom; e <= xTo; e++ {
+		x := xPos(math.Pow(10, float64(e)))
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%.1f" stroke="#ddd"/>`,
+			x, marginTop, x, marginTop+plotHeight)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">1e%d</text>`,
+			x, marginTop+plotHeight+16, e)
+	}
+	for e := yFrom; e <= yTo; e++ {
+		y := yPos(math.Pow(10, float64(e)))
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#ddd"/>`,
+			marginLeft, y, marginLeft+plotWidth, y)
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end">1e%d</text>`,
+			marginLeft-6, y+4, e)
+	}
+	fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%.0f" height="%.0f" fill="none" stroke="#333"/>`,
+		marginLeft, marginTop, plotWidth, plotHeight)
+	fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">elements</text>`,
+		marginLeft+plotWidth/2, chartHeight-10)
+	fmt.Fprintf(&b, `<text x="14" y="%.1f" text-anchor="middle" transform="rotate(-90 14 %.1f)">%s</text>`,
+		marginTop+plotHeight/2, marginTop+plotHeight/2, template.HTMLEscapeString(yLabel))
+
+	for i, s := range all {
+		color := colors[i%len(colors)]
+		measured := polylinePoints(s.points, xPos, yPos)
+		if measured != "" {
+			fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`,
+				measured, color)
+		}
+
+		// The extrapolated line continues from the last measured point.
+		extrapolated := s.extrapolated
+		if len(s.points) > 0 && len(extrapolated) > 0 {
+			extrapolated = append([]point{s.points[len(s.points)-1]}, extrapolated...)
+		}
+		if line := polylinePoints(extrapolated, xPos, yPos); line != "" {
+			fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2" stroke-dasharray="6 4"/>`,
+				line, color)
+		}
+
+		legendY := marginTop + 10 + 18*i
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="%s" stroke-width="2"/>`,
+			marginLeft+plotWidth+10, legendY, marginLeft+plotWidth+30, legendY, color)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d">%s</text>`,
+			marginLeft+plotWidth+35, legendY+4, template.HTMLEscapeString(s.name))
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// polylinePoints returns the SVG points attribute for the specified points
+// using the specified coordinate transformations.
+func polylinePoints(points []point, xPos func(float64) float64, yPos func(float64) float64) string {
+	var coordinates []string
+	for _, p := range points {
+		if p.x <= 0 || p.y <= 0 {
+			continue
+		}
+		coordinates = append(coordinates, fmt.Sprintf("%.1f,%.1f", xPos(p.x), yPos(p.y)))
+	}
+	return strings.Join(coordinates, " ")
+}
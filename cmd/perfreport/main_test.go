@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gitlab.com/dirk.krummacker/sorter/internal/perf"
+)
+
+// testResults is a small report as written by the perf tools.
+var testResults = []perf.Result{
+	{Algorithm: "Bubble", Case: perf.Unsorted, Size: 100, Micros: 20},
+	{Algorithm: "Bubble", Case: perf.Unsorted, Size: 1000, Micros: 2000},
+	{Algorithm: "Bubble", Case: perf.Unsorted, Size: 10000, Micros: 200000, Extrapolated: true},
+	{Algorithm: "Quick", Case: perf.Unsorted, Size: 100, Micros: 4},
+	{Algorithm: "Quick", Case: perf.Unsorted, Size: 1000, Micros: 0},
+	{Algorithm: "Quick", Case: perf.Unsorted, Size: 10000, Micros: 500},
+	{Algorithm: "Standard", Case: perf.Unsorted, Size: 100, Micros: 2},
+	{Algorithm: "Standard", Case: perf.Unsorted, Size: 1000, Micros: 40},
+	{Algorithm: "Standard", Case: perf.Unsorted, Size: 10000, Micros: 1000},
+}
+
+// TestSpeedups tests the Speedups function.
+func TestSpeedups(t *testing.T) {
+	want := []series{
+		{name: "Bubble", points: []point{{100, 0.1}, {1000, 0.02}}},
+		{name: "Quick", points: []point{{100, 0.5}, {10000, 2}}},
+	}
+	got := Speedups(testResults, "Standard")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v but want %v", got, want)
+	}
+}
+
+// TestTableRows tests the tableRows function.
+func TestTableRows(t *testing.T) {
+	want := []row{
+		{Algorithm: "Bubble", Cells: []string{"20", "2000", "~200000"}},
+		{Algorithm: "Quick", Cells: []string{"4", "0", "500"}},
+		{Algorithm: "Standard", Cells: []string{"2", "40", "1000"}},
+	}
+	got := tableRows(testResults)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v but want %v", got, want)
+	}
+}
+
+// TestWriteHTML tests that WriteHTML renders a page with inline SVG charts.
+func TestWriteHTML(t *testing.T) {
+	var b strings.Builder
+	report := perf.Report{Tool: "perftest", Baseline: "Standard", Loops: 10, Results: testResults}
+	if err := WriteHTML(&b, report); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"Case: unsorted",
+		"<svg",
+		"<polyline",
+		`stroke-dasharray="6 4"`,
+		"Speedup vs. Standard",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got no %q in the page", want)
+		}
+	}
+	if strings.Contains(got, "Case: sorted") {
+		t.Errorf("got a section for a case without results")
+	}
+}
+
+// TestRun tests that run writes the HTML report of a JSON report file and
+// fails if the output file cannot be written.
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "results.json")
+	report := perf.Report{Tool: "perftest", Baseline: "Standard", Loops: 10, Results: testResults}
+	if err := perf.WriteReport(in, report); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "report.html")
+	if err := run(in, out); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := os.ReadFile(out); err != nil || !strings.Contains(string(got), "</html>") {
+		t.Errorf("got %d bytes and %v but want a complete page", len(got), err)
+	}
+	if err := run(in, filepath.Join(dir, "missing", "report.html")); err == nil {
+		t.Errorf("got no error for an output file in a missing directory")
+	}
+}
@@ -0,0 +1,361 @@
+package extsort
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gitlab.com/dirk.krummacker/sorter/internal/gsorter"
+	"gitlab.com/dirk.krummacker/sorter/internal/merge"
+)
+
+// The defaults of the options.
+const (
+	DefaultMemoryBudget = 64 << 20
+	DefaultFanIn        = 64
+)
+
+// bufferSize is the size of the buffers for reading and writing runs.
+const bufferSize = 64 << 10
+
+// recordOverhead is the memory that a record needs besides its bytes, which
+// is the size of its span.
+const recordOverhead = 16
+
+// Options configure an external sort. The zero value uses the defaults.
+type Options struct {
+	// TempDir is the directory for the temporary files. If it is empty, the
+	// default directory for temporary files is used.
+	TempDir string
+
+	// MemoryBudget is the number of bytes that the records of a chunk may
+	// use. Larger budgets give fewer and longer runs.
+	MemoryBudget int
+
+	// FanIn is the maximum number of runs that are merged at once. If there
+	// are more runs, they are merged in several passes. Values below 2 are
+	// raised to 2.
+	FanIn int
+
+	// SortFunction sorts the chunks. It defaults to gsorter.GoroutineSort.
+	SortFunction func(sort.Interface)
+
+	// Compare compares two records. It defaults to bytes.Compare.
+	Compare func(a, b []byte) int
+}
+
+// withDefaults returns the options with the defaults for unset fields.
+func (o Options) withDefaults() Options {
+	if o.MemoryBudget <= 0 {
+		o.MemoryBudget = DefaultMemoryBudget
+	}
+	if o.FanIn == 0 {
+		o.FanIn = DefaultFanIn
+	}
+	o.FanIn = max(o.FanIn, 2)
+	if o.SortFunction == nil {
+		o.SortFunction = gsorter.GoroutineSort
+	}
+	if o.Compare == nil {
+		o.Compare = bytes.Compare
+	}
+	return o
+}
+
+// Sort reads newline-terminated records from the specified reader and
+// writes them sorted to the specified writer, using no more memory for
+// records than the budget. It reads chunks that fit the budget, sorts them
+// with the sort function, writes them as sorted runs to temporary files and
+// merges the runs. A missing newline after the last record is added. The
+// temporary files are removed when Sort returns, also on errors.
+func Sort(r io.Reader, w io.Writer, options Options) (err error) {
+	options = options.withDefaults()
+	dir, err := os.MkdirTemp(options.TempDir, "extsort-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, os.RemoveAll(dir))
+	}()
+
+	s := &externalSort{options: options, dir: dir}
+	runs, err := s.createRuns(bufio.NewReaderSize(r, bufferSize), w)
+	if err != nil || len(runs) == 0 {
+		return err
+	}
+	for len(runs) > options.FanIn {
+		if runs, err = s.mergePass(runs); err != nil {
+			return err
+		}
+	}
+	writer := bufio.NewWriterSize(w, bufferSize)
+	if err := s.merge(runs, writer); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// externalSort holds the state of a call to Sort.
+type externalSort struct {
+	options Options
+	dir     string // the temporary directory
+	runs    int    // the number of runs created so far, for file names
+}
+
+// chunk holds records that are sorted in memory. The records of a chunk are
+// stored one after the other in a single arena, so that reading a chunk
+// needs few allocations.
+type chunk struct {
+	arena   []byte
+	spans   []span
+	compare func(a, b []byte) int
+}
+
+// span is the start and end index of a record in the arena.
+type span struct {
+	start, end int
+}
+
+func (c *chunk) Len() int           { return len(c.spans) }
+func (c *chunk) Less(i, j int) bool { return c.compare(c.record(i), c.record(j)) < 0 }
+func (c *chunk) Swap(i, j int)      { c.spans[i], c.spans[j] = c.spans[j], c.spans[i] }
+
+// record returns the record at the specified index.
+func (c *chunk) record(i int) []byte {
+	return c.arena[c.spans[i].start:c.spans[i].end]
+}
+
+// add adds the specified record to the chunk if it fits the memory budget.
+// The first record of a chunk is always added, even if it is larger.
+func (c *chunk) add(record []byte, budget int) bool {
+	used := len(c.arena) + len(c.spans)*recordOverhead
+	if len(c.spans) > 0 && used+len(record)+recordOverhead > budget {
+		return false
+	}
+	if needed := len(c.arena) + len(record); needed > cap(c.arena) {
+		// Grows the arena like append, but not beyond the budget.
+		arena := make([]byte, len(c.arena), max(min(2*cap(c.arena), budget), needed))
+		copy(arena, c.arena)
+		c.arena = arena
+	}
+	c.spans = append(c.spans, span{len(c.arena), len(c.arena) + len(record)})
+	c.arena = append(c.arena, record...)
+	return true
+}
+
+// reset empties the chunk but keeps its memory.
+func (c *chunk) reset() {
+	c.arena, c.spans = c.arena[:0], c.spans[:0]
+}
+
+// createRuns reads the input in chunks, sorts them and writes them to
+// temporary files, whose names it returns. If the whole input fits into a
+// single chunk, it is written directly to the specified writer instead.
+func (s *externalSort) createRuns(reader *bufio.Reader, w io.Writer) ([]string, error) {
+	var runs []string
+	c := &chunk{compare: s.options.Compare}
+	var pending []byte // a record that did not fit into the previous chunk
+	for {
+		done := false
+		for !done {
+			record := pending
+			pending = nil
+			if record == nil {
+				var err error
+				record, err = readRecord(reader)
+				if err == io.EOF {
+					done = true
+					break
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+			if !c.add(record, s.options.MemoryBudget) {
+				pending = record // stays valid, because nothing is read meanwhile
+				break
+			}
+		}
+		s.options.SortFunction(c)
+		if done && len(runs) == 0 {
+			writer := bufio.NewWriterSize(w, bufferSize)
+			if err := writeChunk(writer, c); err != nil {
+				return nil, err
+			}
+			return nil, writer.Flush()
+		}
+		if c.Len() > 0 {
+			name, err := s.writeRun(c)
+			if err != nil {
+				return nil, err
+			}
+			runs = append(runs, name)
+		}
+		if done {
+			return runs, nil
+		}
+		c.reset()
+	}
+}
+
+// readRecord returns the next record of the reader without its newline. The
+// record is only valid until the next read.
+func readRecord(reader *bufio.Reader) ([]byte, error) {
+	line, err := reader.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		// The record is longer than the buffer.
+		long := append([]byte{}, line...)
+		for err == bufio.ErrBufferFull {
+			line, err = reader.ReadSlice('\n')
+			long = append(long, line...)
+		}
+		line = long
+	}
+	if err == io.EOF && len(line) > 0 {
+		err = nil // the last record lacks a newline
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(line, []byte{'\n'}), nil
+}
+
+// writeChunk writes the records of the specified chunk to the writer, each
+// followed by a newline.
+func writeChunk(writer *bufio.Writer, c *chunk) error {
+	for i := range c.spans {
+		if _, err := writer.Write(c.record(i)); err != nil {
+			return err
+		}
+		if err := writer.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRun writes the records of the specified sorted chunk to a new
+// temporary file and returns its name.
+func (s *externalSort) writeRun(c *chunk) (string, error) {
+	file, writer, err := s.createRun()
+	if err != nil {
+		return "", err
+	}
+	if err := writeChunk(writer, c); err != nil {
+		file.Close()
+		return "", err
+	}
+	return file.Name(), closeRun(file, writer)
+}
+
+// createRun creates a new temporary file for a run.
+func (s *externalSort) createRun() (*os.File, *bufio.Writer, error) {
+	s.runs++
+	file, err := os.Create(filepath.Join(s.dir, fmt.Sprintf("run-%06d", s.runs)))
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, bufio.NewWriterSize(file, bufferSize), nil
+}
+
+// closeRun flushes the writer of a run and closes its file.
+func closeRun(file *os.File, writer *bufio.Writer) error {
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// mergePass merges the specified runs in groups of the fan-in into new runs
+// and removes the merged ones. It returns the names of the new runs.
+func (s *externalSort) mergePass(runs []string) ([]string, error) {
+	var merged []string
+	for from := 0; from < len(runs); from += s.options.FanIn {
+		group := runs[from:min(from+s.options.FanIn, len(runs))]
+		if len(group) == 1 {
+			merged = append(merged, group[0])
+			continue
+		}
+		file, writer, err := s.createRun()
+		if err != nil {
+			return nil, err
+		}
+		if err := s.merge(group, writer); err != nil {
+			file.Close()
+			return nil, err
+		}
+		if err := closeRun(file, writer); err != nil {
+			return nil, err
+		}
+		for _, run := range group {
+			if err := os.Remove(run); err != nil {
+				return nil, err
+			}
+		}
+		merged = append(merged, file.Name())
+	}
+	return merged, nil
+}
+
+// merge merges the specified runs into the writer. Equal records are taken
+// from the runs in the order of the runs, so the merge is stable.
+func (s *externalSort) merge(runs []string, writer *bufio.Writer) error {
+	sources := make([]*runReader, len(runs))
+	pulls := make([]func() ([]byte, bool), len(runs))
+	for i, run := range runs {
+		file, err := os.Open(run)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		sources[i] = &runReader{reader: bufio.NewReaderSize(file, bufferSize)}
+		pulls[i] = sources[i].next
+	}
+	next := merge.Merger[[]byte]{Comparator: s.options.Compare}.Pulls(pulls...)
+	for record, ok := next(); ok; record, ok = next() {
+		if _, err := writer.Write(record); err != nil {
+			return err
+		}
+		if err := writer.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	for _, source := range sources {
+		if source.err != nil {
+			return source.err
+		}
+	}
+	return nil
+}
+
+// runReader reads the records of a run one by one. The records alternate
+// between two buffers, because the merge still holds the previous record of
+// a run while it reads the next one.
+type runReader struct {
+	reader  *bufio.Reader
+	buffers [2][]byte
+	current int   // the index of the buffer of the current record
+	err     error // the first error other than io.EOF
+}
+
+// next returns the next record of the run and true, or false at the end of
+// the run or after an error. The record stays valid until the record after
+// the next one is read.
+func (r *runReader) next() ([]byte, bool) {
+	record, err := readRecord(r.reader)
+	if err != nil {
+		if err != io.EOF {
+			r.err = err
+		}
+		return nil, false
+	}
+	r.current ^= 1
+	r.buffers[r.current] = append(r.buffers[r.current][:0], record...)
+	return r.buffers[r.current], true
+}
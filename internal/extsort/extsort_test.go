@@ -0,0 +1,204 @@
+package extsort
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"gitlab.com/dirk.krummacker/sorter/internal/gsorter"
+)
+
+// randomLines returns the specified number of random lines of up to the
+// specified length, including empty lines and duplicates.
+func randomLines(count int, maxLength int) []string {
+	lines := make([]string, count)
+	for i := range lines {
+		line := make([]byte, rand.Intn(maxLength+1))
+		for j := range line {
+			line[j] = byte('a' + rand.Intn(4))
+		}
+		lines[i] = string(line)
+	}
+	return lines
+}
+
+// joinLines returns the specified lines with every line terminated by a
+// newline, so that an empty last line is part of the input, too.
+func joinLines(lines []string) string {
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// sortedOutput returns the expected output for the specified lines.
+func sortedOutput(lines []string) string {
+	sorted := append([]string{}, lines...)
+	sort.Strings(sorted)
+	return joinLines(sorted)
+}
+
+// TestSort tests the external sort with different budgets, fan-ins and sort
+// functions, from a single in-memory chunk to several merge passes.
+func TestSort(t *testing.T) {
+	lines := randomLines(5000, 20)
+	tests := map[string]Options{
+		"defaults":        {},
+		"single_run":      {MemoryBudget: 1 << 20},
+		"one_merge_pass":  {MemoryBudget: 16 << 10},
+		"many_passes":     {MemoryBudget: 1 << 10, FanIn: 2},
+		"fan_in_three":    {MemoryBudget: 2 << 10, FanIn: 3},
+		"fan_in_too_low":  {MemoryBudget: 4 << 10, FanIn: 1},
+		"quick_sort":      {MemoryBudget: 4 << 10, SortFunction: gsorter.QuickSort},
+		"standard_sort":   {MemoryBudget: 4 << 10, SortFunction: sort.Sort},
+		"tiny_budget":     {MemoryBudget: 1},
+		"explicit_tmpdir": {MemoryBudget: 4 << 10, TempDir: t.TempDir()},
+	}
+	want := sortedOutput(lines)
+	for name, options := range tests {
+		var output bytes.Buffer
+		if err := Sort(strings.NewReader(joinLines(lines)), &output, options); err != nil {
+			t.Errorf("%s: got error %v", name, err)
+			continue
+		}
+		if output.String() != want {
+			t.Errorf("%s: got wrong output of %d bytes", name, output.Len())
+		}
+	}
+}
+
+// TestSortEdgeCases tests inputs with special lines.
+func TestSortEdgeCases(t *testing.T) {
+	longLine := strings.Repeat("x", 200<<10)
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"empty_input":        {"", ""},
+		"only_newline":       {"\n", "\n"},
+		"missing_newline":    {"b\na", "a\nb\n"},
+		"empty_lines":        {"b\n\na\n\n", "\n\na\nb\n"},
+		"carriage_returns":   {"b\r\na\r\n", "a\r\nb\r\n"},
+		"binary":             {"\xff\n\x00\n\x80\n", "\x00\n\x80\n\xff\n"},
+		"longer_than_buffer": {"z\n" + longLine + "\na\n", "a\n" + longLine + "\nz\n"},
+	}
+	for name, test := range tests {
+		for _, budget := range []int{1, 1 << 20} {
+			var output bytes.Buffer
+			err := Sort(strings.NewReader(test.input), &output, Options{MemoryBudget: budget})
+			if err != nil {
+				t.Errorf("%s: got error %v", name, err)
+			}
+			if output.String() != test.want {
+				t.Errorf("%s/budget=%d: got %q but want %q", name, budget, shorten(output.String()), shorten(test.want))
+			}
+		}
+	}
+}
+
+// shorten returns the specified string, shortened for error messages.
+func shorten(s string) string {
+	if len(s) > 50 {
+		return s[:50] + "..."
+	}
+	return s
+}
+
+// TestSortCompare tests a custom comparison, and that the merge keeps equal
+// records of different runs in input order.
+func TestSortCompare(t *testing.T) {
+	var lines []string
+	for i := 0; i < 1000; i++ {
+		lines = append(lines, fmt.Sprintf("%d %04d", i%7, i))
+	}
+	byFirstField := func(a, b []byte) int {
+		return bytes.Compare(a[:bytes.IndexByte(a, ' ')], b[:bytes.IndexByte(b, ' ')])
+	}
+
+	// With one record per run, only the merge decides the order of ties.
+	var output bytes.Buffer
+	options := Options{MemoryBudget: 1, FanIn: 4, Compare: byFirstField}
+	if err := Sort(strings.NewReader(joinLines(lines)), &output, options); err != nil {
+		t.Fatal(err)
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i][0] < lines[j][0] })
+	if want := joinLines(lines); output.String() != want {
+		t.Errorf("got unstable or wrong output")
+	}
+}
+
+// failingReader returns an error after the specified number of bytes.
+type failingReader struct {
+	reader io.Reader
+	bytes  int
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.bytes <= 0 {
+		return 0, errors.New("read failed")
+	}
+	n, err := r.reader.Read(p[:min(len(p), r.bytes)])
+	r.bytes -= n
+	return n, err
+}
+
+// failingWriter returns an error after the specified number of bytes.
+type failingWriter struct {
+	bytes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.bytes {
+		return 0, errors.New("write failed")
+	}
+	w.bytes -= len(p)
+	return len(p), nil
+}
+
+// TestSortCleanup tests that errors are returned and that no temporary files
+// are left behind, neither on success nor on errors.
+func TestSortCleanup(t *testing.T) {
+	input := joinLines(randomLines(5000, 20))
+	tests := map[string]struct {
+		reader  io.Reader
+		writer  io.Writer
+		wantErr bool
+	}{
+		"success":         {strings.NewReader(input), io.Discard, false},
+		"read_error":      {&failingReader{strings.NewReader(input), 30000}, io.Discard, true},
+		"write_error":     {strings.NewReader(input), &failingWriter{1000}, true},
+		"late_read_error": {&failingReader{strings.NewReader(input), len(input) - 1}, io.Discard, true},
+	}
+	for name, test := range tests {
+		dir := t.TempDir()
+		err := Sort(test.reader, test.writer, Options{TempDir: dir, MemoryBudget: 4 << 10, FanIn: 3})
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: got error %v but want error %v", name, err, test.wantErr)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Errorf("%s: got %d temporary files left", name, len(entries))
+		}
+	}
+	if err := Sort(strings.NewReader(input), io.Discard, Options{TempDir: "/nonexistent/dir"}); err == nil {
+		t.Errorf("missing temporary directory: got no error")
+	}
+}
+
+// BenchmarkSort measures the external sort of 1 MB of lines with a budget
+// that gives 16 runs.
+func BenchmarkSort(b *testing.B) {
+	input := joinLines(randomLines(50000, 40))
+	for i := 0; i < b.N; i++ {
+		if err := Sort(strings.NewReader(input), io.Discard, Options{MemoryBudget: len(input) / 12}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
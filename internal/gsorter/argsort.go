@@ -0,0 +1,138 @@
+package gsorter
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+)
+
+// Argsort returns the permutation that sorts the specified data ascending
+// using the specified sort function: data[perm[0]], data[perm[1]], ... is
+// sorted. The data itself is not modified.
+func Argsort[T cmp.Ordered](data []T, sortFunction func(sort.Interface)) []int {
+	return ArgsortFunc(data, cmp.Compare[T], sortFunction)
+}
+
+// StableArgsort is like Argsort but equal elements keep their order, so that
+// the permutation is the same for every sort function.
+func StableArgsort[T cmp.Ordered](data []T, sortFunction func(sort.Interface)) []int {
+	return StableArgsortFunc(data, cmp.Compare[T], sortFunction)
+}
+
+// ArgsortFunc returns the permutation that sorts the specified data in the
+// order of the specified comparator using the specified sort function. The
+// data itself is not modified.
+func ArgsortFunc[T any](data []T, comparator Comparator[T], sortFunction func(sort.Interface)) []int {
+	perm := identityPermutation(len(data))
+	sortFunction(argsortSortable[T]{data, perm, comparator, false})
+	return perm
+}
+
+// StableArgsortFunc is like ArgsortFunc but equal elements keep their order,
+// so that the permutation is the same for every sort function.
+func StableArgsortFunc[T any](data []T, comparator Comparator[T], sortFunction func(sort.Interface)) []int {
+	perm := identityPermutation(len(data))
+	sortFunction(argsortSortable[T]{data, perm, comparator, true})
+	return perm
+}
+
+// ApplyPermutation rearranges the specified data in place so that the
+// element at index i is the one that was at index perm[i] before, as for a
+// permutation returned by Argsort. It follows the cycles of the permutation,
+// so it moves every element once and needs no extra memory. The permutation
+// is modified while the function runs and restored before it returns. Applying
+// the same permutation to several slices reorders them consistently. It
+// panics if perm is no permutation of the indexes of data, without modifying
+// either of them.
+func ApplyPermutation[T any](data []T, perm []int) {
+	if len(data) != len(perm) {
+		panic("gsorter: data and permutation have different lengths")
+	}
+	checkPermutation(perm)
+	for start := range perm {
+		if perm[start] < 0 {
+			continue // already moved as part of an earlier cycle
+		}
+		first := data[start]
+		current := start
+		for {
+			next := perm[current]
+			perm[current] = ^next // mark as moved
+			if next == start {
+				data[current] = first
+				break
+			}
+			data[current] = data[next]
+			current = next
+		}
+	}
+	for i := range perm {
+		perm[i] = ^perm[i]
+	}
+}
+
+// InvertPermutation returns the inverse of the specified permutation. If perm
+// sorts data, the inverse gives the position of every element of data in the
+// sorted order.
+func InvertPermutation(perm []int) []int {
+	inverse := make([]int, len(perm))
+	for i, p := range perm {
+		inverse[p] = i
+	}
+	return inverse
+}
+
+// checkPermutation panics unless the specified permutation contains every
+// index in [0, len(perm)) exactly once. It marks the indexes that it has seen
+// in perm itself, so it needs no extra memory, and restores perm before it
+// returns or panics.
+func checkPermutation(perm []int) {
+	for _, p := range perm {
+		if p < 0 || p >= len(perm) {
+			panic(fmt.Sprintf("gsorter: permutation index %d out of range [0:%d]", p, len(perm)))
+		}
+	}
+	duplicate := -1
+	for _, p := range perm {
+		if p < 0 {
+			p = ^p
+		}
+		if perm[p] < 0 {
+			duplicate = p
+			break
+		}
+		perm[p] = ^perm[p] // mark index p as seen
+	}
+	for i, p := range perm {
+		if p < 0 {
+			perm[i] = ^p
+		}
+	}
+	if duplicate >= 0 {
+		panic(fmt.Sprintf("gsorter: permutation index %d occurs more than once", duplicate))
+	}
+}
+
+// identityPermutation returns the permutation 0, 1, ..., length-1.
+func identityPermutation(length int) []int {
+	perm := make([]int, length)
+	for i := range perm {
+		perm[i] = i
+	}
+	return perm
+}
+
+// argsortSortable sorts a permutation by the elements of data it points to.
+type argsortSortable[T any] struct {
+	data       []T
+	perm       []int
+	comparator Comparator[T]
+	stable     bool
+}
+
+func (a argsortSortable[T]) Len() int { return len(a.perm) }
+func (a argsortSortable[T]) Less(i, j int) bool {
+	result := a.comparator.Compare(a.data[a.perm[i]], a.data[a.perm[j]])
+	return result < 0 || a.stable && result == 0 && a.perm[i] < a.perm[j]
+}
+func (a argsortSortable[T]) Swap(i, j int) { a.perm[i], a.perm[j] = a.perm[j], a.perm[i] }
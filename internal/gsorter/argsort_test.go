@@ -0,0 +1,184 @@
+package gsorter
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestArgsort tests the argsort functions with all sort functions.
+func TestArgsort(t *testing.T) {
+	for _, sortFunction := range SortFunctions {
+		tests := map[string]struct {
+			slice []int
+			want  []int
+		}{
+			"empty_input": {
+				slice: []int{},
+				want:  []int{},
+			},
+			"one_element": {
+				slice: []int{42},
+				want:  []int{0},
+			},
+			"three_elements_not_sorted": {
+				slice: []int{3, 1, 2},
+				want:  []int{1, 2, 0},
+			},
+			"all_elements_positive_negative": {
+				slice: []int{4, 7, -4, 2, -8, 9, 6},
+				want:  []int{4, 2, 3, 0, 6, 1, 5},
+			},
+		}
+		for name, test := range tests {
+			original := append([]int{}, test.slice...)
+			got := Argsort(test.slice, sortFunction)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%s: got %v but want %v", name, got, test.want)
+			}
+			if !reflect.DeepEqual(test.slice, original) {
+				t.Errorf("%s: got modified data %v", name, test.slice)
+			}
+		}
+	}
+}
+
+// TestStableArgsort tests that the stable argsort functions return the same
+// permutation for every sort function.
+func TestStableArgsort(t *testing.T) {
+	slice := []string{"b", "A", "a", "c", "B", "b", "a"}
+	want := []int{1, 2, 6, 0, 4, 5, 3}
+	ignoreCase := By(strings.ToLower)
+	for _, sortFunction := range SortFunctions {
+		got := StableArgsortFunc(slice, ignoreCase, sortFunction)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v but want %v", got, want)
+		}
+	}
+
+	ints := CreateRandomInts(1000)
+	for i := range ints {
+		ints[i] %= 10
+	}
+	for _, sortFunction := range SortFunctions {
+		perm := StableArgsort(ints, sortFunction)
+		sorted := make([]int, len(ints))
+		for i, p := range perm {
+			sorted[i] = ints[p]
+		}
+		if !IsStablySorted(IntSortable(sorted), perm) {
+			t.Errorf("got unstable permutation")
+		}
+	}
+}
+
+// TestApplyPermutation tests that several columns are reordered consistently.
+func TestApplyPermutation(t *testing.T) {
+	names := []string{"Carol", "Alice", "Dave", "Bob"}
+	ages := []int{35, 30, 25, 25}
+	cities := []string{"Munich", "Munich", "Hamburg", "Berlin"}
+	perm := Argsort(names, QuickSort)
+	original := append([]int{}, perm...)
+
+	ApplyPermutation(names, perm)
+	ApplyPermutation(ages, perm)
+	ApplyPermutation(cities, perm)
+	if !reflect.DeepEqual(perm, original) {
+		t.Errorf("got modified permutation %v but want %v", perm, original)
+	}
+	if want := []string{"Alice", "Bob", "Carol", "Dave"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %v but want %v", names, want)
+	}
+	if want := []int{30, 25, 35, 25}; !reflect.DeepEqual(ages, want) {
+		t.Errorf("got %v but want %v", ages, want)
+	}
+	if want := []string{"Munich", "Berlin", "Munich", "Hamburg"}; !reflect.DeepEqual(cities, want) {
+		t.Errorf("got %v but want %v", cities, want)
+	}
+}
+
+// TestLargeApplyPermutation tests ApplyPermutation against sorting with a
+// large random slice.
+func TestLargeApplyPermutation(t *testing.T) {
+	slice := CreateRandomInts(1000)
+	want := append([]int{}, slice...)
+	sort.Ints(want)
+	ApplyPermutation(slice, Argsort(slice, GoroutineSort))
+	if !reflect.DeepEqual(slice, want) {
+		t.Errorf("got %v but want %v", slice, want)
+	}
+}
+
+// TestApplyInvalidPermutation tests that ApplyPermutation panics for invalid
+// permutations and leaves the data and the permutation unchanged.
+func TestApplyInvalidPermutation(t *testing.T) {
+	tests := map[string]struct {
+		perm []int
+	}{
+		"too_short": {
+			perm: []int{1, 0},
+		},
+		"negative_index": {
+			perm: []int{0, -1, 2},
+		},
+		"index_too_large": {
+			perm: []int{0, 3, 1},
+		},
+		"duplicate_index": {
+			perm: []int{2, 0, 2},
+		},
+		"duplicate_after_cycle": {
+			perm: []int{1, 0, 1},
+		},
+	}
+	for name, test := range tests {
+		data := []string{"a", "b", "c"}
+		perm := append([]int{}, test.perm...)
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: got no panic", name)
+				}
+			}()
+			ApplyPermutation(data, perm)
+		}()
+		if !reflect.DeepEqual(data, []string{"a", "b", "c"}) || !reflect.DeepEqual(perm, test.perm) {
+			t.Errorf("%s: got data %v and permutation %v after the panic", name, data, perm)
+		}
+	}
+}
+
+// TestInvertPermutation tests the InvertPermutation function.
+func TestInvertPermutation(t *testing.T) {
+	tests := map[string]struct {
+		perm []int
+		want []int
+	}{
+		"empty_input": {
+			perm: []int{},
+			want: []int{},
+		},
+		"identity": {
+			perm: []int{0, 1, 2},
+			want: []int{0, 1, 2},
+		},
+		"rotation": {
+			perm: []int{1, 2, 3, 0},
+			want: []int{3, 0, 1, 2},
+		},
+	}
+	for name, test := range tests {
+		got := InvertPermutation(test.perm)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+		data := []int{10, 11, 12, 13}[:len(test.perm)]
+		original := append([]int{}, data...)
+		ApplyPermutation(data, test.perm)
+		ApplyPermutation(data, got)
+		if !reflect.DeepEqual(data, original) {
+			t.Errorf("%s: got %v after applying the inverse but want %v", name, data, original)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package gsorter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Normalization selects the Unicode normalization form that strings are
+// compared in.
+type Normalization int
+
+const (
+	NoNormalization Normalization = iota // compare the bytes as they are
+	NormalizeNFC                         // compare the canonical compositions
+	NormalizeNFD                         // compare the canonical decompositions
+)
+
+// StringOrder specifies an order of strings. The zero value is the byte-wise
+// ascending order of StringSortable.
+type StringOrder struct {
+	Descending    bool
+	IgnoreCase    bool          // compare letters regardless of case
+	Normalization Normalization // ignored if a Collator is set
+
+	// Collator compares the strings in a locale-aware order. If IgnoreCase
+	// is set, the collator also ignores the case.
+	Collator *Collator
+}
+
+// Strings returns a sort.Interface for the specified data that sorts it in
+// this order.
+func (o StringOrder) Strings(data []string) sort.Interface {
+	return comparatorSortable[string]{data, o.Compare}
+}
+
+// Compare compares the specified strings in this order. It returns a
+// negative number, zero or a positive number like strings.Compare.
+func (o StringOrder) Compare(a, b string) int {
+	var result int
+	switch {
+	case o.Collator != nil:
+		result = o.Collator.compare(a, b, o.IgnoreCase)
+	default:
+		switch o.Normalization {
+		case NormalizeNFC:
+			a, b = NFC(a), NFC(b)
+		case NormalizeNFD:
+			a, b = NFD(a), NFD(b)
+		}
+		if o.IgnoreCase {
+			result = compareFold(a, b)
+		} else {
+			result = strings.Compare(a, b)
+		}
+	}
+	if o.Descending {
+		return -result
+	}
+	return result
+}
+
+// compareFold compares the specified strings rune by rune, regardless of the
+// case of letters.
+func compareFold(a, b string) int {
+	for a != "" && b != "" {
+		runeA, sizeA := utf8.DecodeRuneInString(a)
+		runeB, sizeB := utf8.DecodeRuneInString(b)
+		runeA, runeB = unicode.ToLower(runeA), unicode.ToLower(runeB)
+		if runeA != runeB {
+			if runeA < runeB {
+				return -1
+			}
+			return 1
+		}
+		a, b = a[sizeA:], b[sizeB:]
+	}
+	return len(a) - len(b)
+}
+
+// The primary weights of the different kinds of characters. Punctuation and
+// symbols come first, then digits, Latin letters and all other letters.
+const (
+	digitWeight       = 0x10000
+	latinWeight       = 0x20000
+	latinStep         = 0x10 // leaves room for tailored letters
+	otherLetterWeight = 0x40000
+)
+
+// The tertiary weights, which distinguish the case.
+const (
+	lowerWeight     = 1
+	upperWeight     = 2
+	expansionWeight = 3 // later elements of an expanded letter such as ß
+)
+
+// collationElement holds the weights of a character on the three levels
+// of the Unicode Collation Algorithm. Combining marks have no primary weight.
+type collationElement struct {
+	primary   int
+	secondary int
+	tertiary  int
+}
+
+// specialLetters are Latin letters without a canonical decomposition and the
+// letters that they are sorted as. A combining mark after the letters makes
+// them differ on the secondary level.
+var specialLetters = map[rune]string{
+	'ß': "ss",
+	'æ': "ae",
+	'œ': "oe",
+	'ø': "o̸",
+	'đ': "d̵",
+	'ł': "l̷",
+	'ı': "i̇",
+}
+
+// tailorings holds the letters that are sorted differently per language, as
+// the primary weights relative to the letter they follow. The keys of
+// tailorings are the supported locales besides the root locale "".
+var tailorings = map[string]map[rune]int{
+	"de": {}, // umlauts are accented letters, as in the root locale
+	"en": {},
+	"es": {'ñ': latinPrimary('n') + 1},
+	"sv": {'å': latinPrimary('z') + 1, 'ä': latinPrimary('z') + 2, 'æ': latinPrimary('z') + 2,
+		'ö': latinPrimary('z') + 3, 'ø': latinPrimary('z') + 3},
+	"fi": {'å': latinPrimary('z') + 1, 'ä': latinPrimary('z') + 2, 'æ': latinPrimary('z') + 2,
+		'ö': latinPrimary('z') + 3, 'ø': latinPrimary('z') + 3},
+	"da": {'æ': latinPrimary('z') + 1, 'ä': latinPrimary('z') + 1, 'ø': latinPrimary('z') + 2,
+		'ö': latinPrimary('z') + 2, 'å': latinPrimary('z') + 3},
+	"nb": {'æ': latinPrimary('z') + 1, 'ä': latinPrimary('z') + 1, 'ø': latinPrimary('z') + 2,
+		'ö': latinPrimary('z') + 2, 'å': latinPrimary('z') + 3},
+}
+
+// latinPrimary returns the primary weight of the specified lower case letter
+// from a to z.
+func latinPrimary(r rune) int {
+	return latinWeight + int(r-'a')*latinStep
+}
+
+// Collator compares strings in a locale-aware order. It implements a
+// simplified Unicode Collation Algorithm: strings are first compared by their
+// base letters, then by their accents and then by the case of the letters.
+// Strings that are equal on all three levels are compared byte-wise. Letters
+// of scripts other than Latin are compared by code point.
+type Collator struct {
+	tailoring map[rune]int
+}
+
+// NewCollator returns a collator for the specified locale, such as "de",
+// "sv" or "sv-SE". The root locale "" sorts accented letters right after
+// their base letters, which is also the German order.
+func NewCollator(locale string) (*Collator, error) {
+	language := strings.ToLower(locale)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	if language == "" {
+		return &Collator{}, nil
+	}
+	tailoring, ok := tailorings[language]
+	if !ok {
+		return nil, fmt.Errorf("unsupported locale %q", locale)
+	}
+	return &Collator{tailoring}, nil
+}
+
+// Compare compares the specified strings in the order of the collator. It
+// returns a negative number, zero or a positive number like strings.Compare.
+func (c *Collator) Compare(a, b string) int {
+	return c.compare(a, b, false)
+}
+
+// compare compares the specified strings level by level. If ignoreCase is
+// true, the comparison stops after the secondary level.
+func (c *Collator) compare(a, b string, ignoreCase bool) int {
+	elementsA, elementsB := c.elements(a), c.elements(b)
+	if result := compareLevel(elementsA, elementsB, func(e collationElement) int { return e.primary }); result != 0 {
+		return result
+	}
+	if result := compareLevel(elementsA, elementsB, func(e collationElement) int { return e.secondary }); result != 0 {
+		return result
+	}
+	if ignoreCase {
+		return 0
+	}
+	if result := compareLevel(elementsA, elementsB, func(e collationElement) int { return e.tertiary }); result != 0 {
+		return result
+	}
+	return strings.Compare(a, b)
+}
+
+// compareLevel compares the non-zero weights of the specified level.
+func compareLevel(a []collationElement, b []collationElement, weight func(collationElement) int) int {
+	i, j := 0, 0
+	for {
+		for i < len(a) && weight(a[i]) == 0 {
+			i++
+		}
+		for j < len(b) && weight(b[j]) == 0 {
+			j++
+		}
+		switch {
+		case i == len(a) && j == len(b):
+			return 0
+		case i == len(a):
+			return -1
+		case j == len(b):
+			return 1
+		case weight(a[i]) != weight(b[j]):
+			return weight(a[i]) - weight(b[j])
+		}
+		i++
+		j++
+	}
+}
+
+// elements returns the collation elements of the specified string.
+func (c *Collator) elements(s string) []collationElement {
+	var elements []collationElement
+	for _, r := range NFC(s) {
+		lower := unicode.ToLower(r)
+		tertiary := lowerWeight
+		if lower != r {
+			tertiary = upperWeight
+		}
+		if primary, ok := c.tailoring[lower]; ok {
+			elements = append(elements, collationElement{primary, 1, tertiary})
+			continue
+		}
+		expansion, ok := specialLetters[lower]
+		if !ok {
+			expansion = string(decompose(string(lower)))
+		}
+		for i, part := range expansion {
+			element := collationElement{secondary: 1, tertiary: tertiary}
+			switch {
+			case combiningClasses[part] != 0 || unicode.Is(unicode.Mn, part):
+				element = collationElement{secondary: int(part)}
+			case 'a' <= part && part <= 'z':
+				element.primary = latinPrimary(part)
+			case '0' <= part && part <= '9':
+				element.primary = digitWeight + int(part-'0')
+			case unicode.IsLetter(part) || unicode.IsDigit(part):
+				element.primary = otherLetterWeight + int(part)
+			default:
+				element.primary = 1 + int(part) // punctuation, symbols and spaces
+			}
+			if i > 0 && element.primary != 0 {
+				element.tertiary = expansionWeight
+			}
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
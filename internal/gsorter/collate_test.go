@@ -0,0 +1,205 @@
+package gsorter
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// TestStringOrder tests all sort functions with a corpus of strings in
+// different orders and locales.
+func TestStringOrder(t *testing.T) {
+	root := mustCollator(t, "")
+	german := mustCollator(t, "de-DE")
+	swedish := mustCollator(t, "sv")
+	danish := mustCollator(t, "da")
+	spanish := mustCollator(t, "es")
+
+	tests := map[string]struct {
+		order StringOrder
+		input []string
+		want  []string
+	}{
+		"bytes": {
+			order: StringOrder{},
+			input: []string{"b", "a", "C", "ä"},
+			want:  []string{"C", "a", "b", "ä"},
+		},
+		"descending": {
+			order: StringOrder{Descending: true},
+			input: []string{"b", "a", "c"},
+			want:  []string{"c", "b", "a"},
+		},
+		"ignore_case": {
+			order: StringOrder{IgnoreCase: true},
+			input: []string{"cherry", "Banana", "apple"},
+			want:  []string{"apple", "Banana", "cherry"},
+		},
+		"ignore_case_non_ascii": {
+			order: StringOrder{IgnoreCase: true},
+			input: []string{"École", "éclair"},
+			want:  []string{"éclair", "École"},
+		},
+		"ignore_case_descending": {
+			order: StringOrder{IgnoreCase: true, Descending: true},
+			input: []string{"apple", "Cherry", "banana"},
+			want:  []string{"Cherry", "banana", "apple"},
+		},
+		"nfc": {
+			order: StringOrder{Normalization: NormalizeNFC},
+			input: []string{"ét", "f", "e\u0301"},
+			want:  []string{"f", "e\u0301", "ét"},
+		},
+		"nfd": {
+			order: StringOrder{Normalization: NormalizeNFD},
+			input: []string{"f", "ét", "e\u0301"},
+			want:  []string{"e\u0301", "ét", "f"},
+		},
+		"german_umlaut_after_base_letter": {
+			order: StringOrder{Collator: german},
+			input: []string{"Baum", "Bär", "bar", "Bad", "Bar"},
+			want:  []string{"Bad", "bar", "Bar", "Bär", "Baum"},
+		},
+		"german_letters": {
+			order: StringOrder{Collator: german},
+			input: []string{"z", "ö", "o", "å", "ä", "a"},
+			want:  []string{"a", "ä", "å", "o", "ö", "z"},
+		},
+		"german_sharp_s": {
+			order: StringOrder{Collator: german},
+			input: []string{"strasze", "straße", "strasse"},
+			want:  []string{"strasse", "straße", "strasze"},
+		},
+		"german_decomposed": {
+			order: StringOrder{Collator: german},
+			input: []string{"Mu\u0308ller", "Mueller", "Muller", "Mutter"},
+			want:  []string{"Mueller", "Muller", "Mu\u0308ller", "Mutter"},
+		},
+		"german_descending": {
+			order: StringOrder{Collator: german, Descending: true},
+			input: []string{"Äpfel", "Zebra", "apfel"},
+			want:  []string{"Zebra", "Äpfel", "apfel"},
+		},
+		"swedish_letters_after_z": {
+			order: StringOrder{Collator: swedish},
+			input: []string{"ö", "å", "z", "a", "ä", "o"},
+			want:  []string{"a", "o", "z", "å", "ä", "ö"},
+		},
+		"swedish_names": {
+			order: StringOrder{Collator: swedish},
+			input: []string{"Östberg", "Andersson", "Åberg", "Zetterlund", "Ängström"},
+			want:  []string{"Andersson", "Zetterlund", "Åberg", "Ängström", "Östberg"},
+		},
+		"swedish_decomposed": {
+			order: StringOrder{Collator: swedish},
+			input: []string{"a\u030angstrom", "zulu"},
+			want:  []string{"zulu", "a\u030angstrom"},
+		},
+		"danish_letters_after_z": {
+			order: StringOrder{Collator: danish},
+			input: []string{"Åse", "Ødegaard", "Zebra", "Ære"},
+			want:  []string{"Zebra", "Ære", "Ødegaard", "Åse"},
+		},
+		"spanish_enye": {
+			order: StringOrder{Collator: spanish},
+			input: []string{"oso", "ñu", "nube", "nada"},
+			want:  []string{"nada", "nube", "ñu", "oso"},
+		},
+		"root_enye": {
+			order: StringOrder{Collator: root},
+			input: []string{"oso", "ñu", "nube", "nada"},
+			want:  []string{"nada", "ñu", "nube", "oso"},
+		},
+		"root_punctuation_and_digits": {
+			order: StringOrder{Collator: root},
+			input: []string{"ab", "a1", "10", "a-b"},
+			want:  []string{"10", "a-b", "a1", "ab"},
+		},
+		"root_other_scripts_after_latin": {
+			order: StringOrder{Collator: root},
+			input: []string{"Ωμέγα", "zeta", "Alpha"},
+			want:  []string{"Alpha", "zeta", "Ωμέγα"},
+		},
+	}
+	for name, test := range tests {
+		for _, sortFunction := range SortFunctions {
+			slice := shuffledStrings(test.input)
+			sortFunction(test.order.Strings(slice))
+			if !reflect.DeepEqual(slice, test.want) {
+				t.Errorf("%s: got %q but want %q", name, slice, test.want)
+			}
+		}
+	}
+}
+
+// TestCollatorIgnoreCase tests that a collator with IgnoreCase treats strings
+// that differ only in case as equal, but not strings with different accents.
+func TestCollatorIgnoreCase(t *testing.T) {
+	order := StringOrder{Collator: mustCollator(t, "de"), IgnoreCase: true}
+	tests := map[string]struct {
+		a, b string
+		want int
+	}{
+		"case":       {"Straße", "STRASSE", 0},
+		"accent":     {"Bar", "bär", -1},
+		"primary":    {"Bären", "Bart", -1},
+		"decomposed": {"A\u0308pfel", "äpfel", 0},
+	}
+	for name, test := range tests {
+		if got := sign(order.Compare(test.a, test.b)); got != test.want {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+		if got := sign(order.Compare(test.b, test.a)); got != -test.want {
+			t.Errorf("%s: reversed got %v but want %v", name, got, -test.want)
+		}
+	}
+}
+
+// TestNewCollator tests the parsing of locales.
+func TestNewCollator(t *testing.T) {
+	tests := map[string]struct {
+		locale  string
+		wantErr bool
+	}{
+		"root":        {"", false},
+		"english":     {"en", false},
+		"region":      {"sv-SE", false},
+		"underscore":  {"da_DK", false},
+		"upper_case":  {"ES", false},
+		"unsupported": {"xx", true},
+	}
+	for name, test := range tests {
+		_, err := NewCollator(test.locale)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: got error %v but want error %v", name, err, test.wantErr)
+		}
+	}
+}
+
+// mustCollator returns the collator for the specified locale.
+func mustCollator(t *testing.T, locale string) *Collator {
+	t.Helper()
+	collator, err := NewCollator(locale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return collator
+}
+
+// shuffledStrings returns a shuffled copy of the specified strings.
+func shuffledStrings(strings []string) []string {
+	result := append([]string{}, strings...)
+	rand.Shuffle(len(result), func(i, j int) { result[i], result[j] = result[j], result[i] })
+	return result
+}
+
+// sign returns -1, 0 or 1 for negative numbers, zero and positive numbers.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,163 @@
+package gsorter
+
+import (
+	"cmp"
+	"sort"
+)
+
+// Comparator compares two values. It returns a negative number if a is less
+// than b, a positive number if a is greater than b and zero if they are
+// equal, like cmp.Compare. A nil Comparator treats all values as equal.
+//
+// Comparators are combined to order records by several keys, for example:
+//
+//	byCustomer := By(func(o Order) string { return o.Customer })
+//	byDate := ByFunc(func(o Order) time.Time { return o.Date }, time.Time.Compare)
+//	byAmount := By(func(o Order) float64 { return o.Amount })
+//	QuickSort(byCustomer.ThenDesc(byDate).Then(byAmount).Sortable(orders))
+type Comparator[T any] func(a, b T) int
+
+// By returns a comparator that orders values ascending by the specified key.
+// A nil key function treats all values as equal.
+func By[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
+	if key == nil {
+		return nil
+	}
+	return func(a, b T) int { return cmp.Compare(key(a), key(b)) }
+}
+
+// ByDesc returns a comparator that orders values descending by the specified
+// key. A nil key function treats all values as equal.
+func ByDesc[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
+	return By(key).Reverse()
+}
+
+// ByFunc returns a comparator that orders values ascending by the specified
+// key, which is compared with the specified function. This allows keys that
+// are not cmp.Ordered, for example time.Time with time.Time.Compare.
+func ByFunc[T any, K any](key func(T) K, compare func(a, b K) int) Comparator[T] {
+	if key == nil || compare == nil {
+		return nil
+	}
+	return func(a, b T) int { return compare(key(a), key(b)) }
+}
+
+// Compare compares the specified values. Unlike calling c directly, it is
+// safe to use with a nil Comparator.
+func (c Comparator[T]) Compare(a, b T) int {
+	if c == nil {
+		return 0
+	}
+	return c(a, b)
+}
+
+// Then returns a comparator that uses the specified comparator for values
+// that c considers equal.
+func (c Comparator[T]) Then(next Comparator[T]) Comparator[T] {
+	if c == nil {
+		return next
+	}
+	if next == nil {
+		return c
+	}
+	return func(a, b T) int {
+		if result := c(a, b); result != 0 {
+			return result
+		}
+		return next(a, b)
+	}
+}
+
+// ThenDesc returns a comparator that uses the reverse of the specified
+// comparator for values that c considers equal.
+func (c Comparator[T]) ThenDesc(next Comparator[T]) Comparator[T] {
+	return c.Then(next.Reverse())
+}
+
+// Reverse returns a comparator with the opposite order of c.
+func (c Comparator[T]) Reverse() Comparator[T] {
+	if c == nil {
+		return nil
+	}
+	return func(a, b T) int { return c(b, a) }
+}
+
+// NilsFirst returns a comparator for pointers that orders nil pointers
+// before all others and compares the other pointed-to values with c.
+func NilsFirst[T any](c Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return 1
+		}
+		return c.Compare(*a, *b)
+	}
+}
+
+// NilsLast returns a comparator for pointers that orders nil pointers after
+// all others and compares the other pointed-to values with c.
+func NilsLast[T any](c Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		case b == nil:
+			return -1
+		}
+		return c.Compare(*a, *b)
+	}
+}
+
+// Sortable returns a sort.Interface for the specified data that sorts it in
+// the order of c. It can be used with all SortFunctions.
+func (c Comparator[T]) Sortable(data []T) sort.Interface {
+	return comparatorSortable[T]{data, c}
+}
+
+// StableSortable returns a sort.Interface for the specified data that sorts
+// it in the order of c and keeps the original order of equal values. This
+// makes every sort function stable, at the cost of an extra slice with the
+// original positions.
+func (c Comparator[T]) StableSortable(data []T) sort.Interface {
+	positions := make([]int, len(data))
+	for i := range positions {
+		positions[i] = i
+	}
+	return stableComparatorSortable[T]{data, positions, c}
+}
+
+// comparatorSortable sorts values with a comparator.
+type comparatorSortable[T any] struct {
+	data       []T
+	comparator Comparator[T]
+}
+
+func (a comparatorSortable[T]) Len() int { return len(a.data) }
+func (a comparatorSortable[T]) Less(i, j int) bool {
+	return a.comparator.Compare(a.data[i], a.data[j]) < 0
+}
+func (a comparatorSortable[T]) Swap(i, j int) { a.data[i], a.data[j] = a.data[j], a.data[i] }
+
+// stableComparatorSortable sorts values with a comparator and breaks ties by
+// the original positions of the values.
+type stableComparatorSortable[T any] struct {
+	data       []T
+	positions  []int
+	comparator Comparator[T]
+}
+
+func (a stableComparatorSortable[T]) Len() int { return len(a.data) }
+func (a stableComparatorSortable[T]) Less(i, j int) bool {
+	result := a.comparator.Compare(a.data[i], a.data[j])
+	return result < 0 || result == 0 && a.positions[i] < a.positions[j]
+}
+func (a stableComparatorSortable[T]) Swap(i, j int) {
+	a.data[i], a.data[j] = a.data[j], a.data[i]
+	a.positions[i], a.positions[j] = a.positions[j], a.positions[i]
+}
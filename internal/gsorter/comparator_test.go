@@ -0,0 +1,117 @@
+package gsorter
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// order is a record with several keys to sort by.
+type order struct {
+	customer string
+	date     time.Time
+	amount   float64
+}
+
+// TestComparator tests all sort functions with combined comparators.
+func TestComparator(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, time.March, d, 0, 0, 0, 0, time.UTC) }
+	o1 := order{"Alice", day(1), 10}
+	o2 := order{"Alice", day(2), 5}
+	o3 := order{"Alice", day(2), 7}
+	o4 := order{"Bob", day(1), 3}
+	o5 := order{"Charlie", day(3), 1}
+	input := []order{o4, o3, o5, o1, o2}
+
+	byCustomer := By(func(o order) string { return o.customer })
+	byDate := ByFunc(func(o order) time.Time { return o.date }, time.Time.Compare)
+	byAmount := By(func(o order) float64 { return o.amount })
+
+	tests := map[string]struct {
+		comparator Comparator[order]
+		want       []order
+	}{
+		"customer_date_desc_amount": {
+			comparator: byCustomer.ThenDesc(byDate).Then(byAmount),
+			want:       []order{o2, o3, o1, o4, o5},
+		},
+		"amount_desc": {
+			comparator: ByDesc(func(o order) float64 { return o.amount }),
+			want:       []order{o1, o3, o2, o4, o5},
+		},
+		"date_amount_desc": {
+			comparator: byDate.ThenDesc(byAmount),
+			want:       []order{o1, o4, o3, o2, o5},
+		},
+		"reversed": {
+			comparator: byCustomer.Then(byDate).Then(byAmount).Reverse(),
+			want:       []order{o5, o4, o3, o2, o1},
+		},
+		"nil_comparators_ignored": {
+			comparator: Comparator[order](nil).Then(byAmount).Then(nil).ThenDesc(nil),
+			want:       []order{o5, o4, o2, o3, o1},
+		},
+	}
+	for name, test := range tests {
+		for _, sortFunction := range SortFunctions {
+			slice := append([]order{}, input...)
+			sortFunction(test.comparator.Sortable(slice))
+			if !reflect.DeepEqual(slice, test.want) {
+				t.Errorf("%s: got %v but want %v", name, slice, test.want)
+			}
+		}
+	}
+}
+
+// TestComparatorNils tests the comparators for pointers.
+func TestComparatorNils(t *testing.T) {
+	a, b := &order{customer: "Alice"}, &order{customer: "Bob"}
+	byCustomer := By(func(o order) string { return o.customer })
+	tests := map[string]struct {
+		comparator Comparator[*order]
+		want       []*order
+	}{
+		"nils_first": {
+			comparator: NilsFirst(byCustomer),
+			want:       []*order{nil, nil, a, b},
+		},
+		"nils_last": {
+			comparator: NilsLast(byCustomer),
+			want:       []*order{a, b, nil, nil},
+		},
+		"nils_last_desc": {
+			comparator: NilsLast(byCustomer.Reverse()),
+			want:       []*order{b, a, nil, nil},
+		},
+	}
+	for name, test := range tests {
+		for _, sortFunction := range SortFunctions {
+			slice := []*order{b, nil, a, nil}
+			sortFunction(test.comparator.Sortable(slice))
+			if !reflect.DeepEqual(slice, test.want) {
+				t.Errorf("%s: got %v but want %v", name, slice, test.want)
+			}
+		}
+	}
+}
+
+// TestComparatorStable tests that all sort functions keep the order of equal
+// values with a stable sortable.
+func TestComparatorStable(t *testing.T) {
+	byKey := By(func(r record) int { return r.key })
+	for _, sortFunction := range SortFunctions {
+		slice := make([]record, 2000)
+		for i := range slice {
+			slice[i] = record{key: rand.Intn(50), index: i}
+		}
+		sortFunction(byKey.StableSortable(slice))
+		for i := 1; i < len(slice); i++ {
+			if slice[i-1].key > slice[i].key ||
+				slice[i-1].key == slice[i].key && slice[i-1].index > slice[i].index {
+				t.Errorf("got unstable or unsorted order at index %d", i)
+				break
+			}
+		}
+	}
+}
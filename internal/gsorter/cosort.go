@@ -0,0 +1,52 @@
+package gsorter
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+)
+
+// CoSortable is a sort.Interface that sorts a slice of keys and moves the
+// elements of any number of companion slices in lockstep, without an index
+// slice. It can be used with all SortFunctions, including GoroutineSort,
+// because the swaps of disjoint index ranges do not interfere.
+type CoSortable[T any] struct {
+	keys       []T
+	comparator Comparator[T]
+	swappers   []func(i, j int)
+}
+
+// NewCoSortable returns a CoSortable that sorts the specified keys ascending
+// and moves the elements of the specified companion slices along. An error is
+// returned if a companion is no slice or has another length than keys.
+func NewCoSortable[T cmp.Ordered](keys []T, companions ...any) (*CoSortable[T], error) {
+	return NewCoSortableFunc(keys, cmp.Compare[T], companions...)
+}
+
+// NewCoSortableFunc is like NewCoSortable but sorts the keys in the order of
+// the specified comparator.
+func NewCoSortableFunc[T any](keys []T, comparator Comparator[T], companions ...any) (*CoSortable[T], error) {
+	swappers := make([]func(i, j int), len(companions))
+	for i, companion := range companions {
+		value := reflect.ValueOf(companion)
+		if value.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("companion %d is of type %T, want a slice", i, companion)
+		}
+		if value.Len() != len(keys) {
+			return nil, fmt.Errorf("companion %d has length %d, want %d like the keys", i, value.Len(), len(keys))
+		}
+		swappers[i] = reflect.Swapper(companion)
+	}
+	return &CoSortable[T]{keys, comparator, swappers}, nil
+}
+
+func (a *CoSortable[T]) Len() int { return len(a.keys) }
+func (a *CoSortable[T]) Less(i, j int) bool {
+	return a.comparator.Compare(a.keys[i], a.keys[j]) < 0
+}
+func (a *CoSortable[T]) Swap(i, j int) {
+	a.keys[i], a.keys[j] = a.keys[j], a.keys[i]
+	for _, swap := range a.swappers {
+		swap(i, j)
+	}
+}
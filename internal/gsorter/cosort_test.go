@@ -0,0 +1,91 @@
+package gsorter
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestCoSortable tests all sort functions with companion slices.
+func TestCoSortable(t *testing.T) {
+	for _, sortFunction := range SortFunctions {
+		keys := []int{3, 1, 4, 2}
+		names := []string{"three", "one", "four", "two"}
+		times := []time.Time{time.Unix(3, 0), time.Unix(1, 0), time.Unix(4, 0), time.Unix(2, 0)}
+		data, err := NewCoSortable(keys, names, times)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sortFunction(data)
+		if want := []int{1, 2, 3, 4}; !reflect.DeepEqual(keys, want) {
+			t.Errorf("got keys %v but want %v", keys, want)
+		}
+		if want := []string{"one", "two", "three", "four"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("got names %v but want %v", names, want)
+		}
+		for i, time := range times {
+			if time.Unix() != int64(keys[i]) {
+				t.Errorf("got times %v for keys %v", times, keys)
+				break
+			}
+		}
+	}
+}
+
+// TestLargeCoSortable tests the fast sort functions with a large slice, which
+// makes GoroutineSort swap in several goroutines. Run it with the race
+// detector to check that the swaps do not interfere:
+//
+//	go test -race -run CoSortable ./internal/gsorter
+func TestLargeCoSortable(t *testing.T) {
+	for _, sortFunction := range []func(sort.Interface){sort.Sort, QuickSort, GoroutineSort} {
+		keys := CreateRandomStrings(20000, 6)
+		lengths := make([]int, len(keys))
+		copies := make([]string, len(keys))
+		for i, key := range keys {
+			lengths[i] = len(key) + i
+			copies[i] = key
+		}
+		data, err := NewCoSortableFunc(keys, By(func(s string) string { return s }), lengths, copies)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sortFunction(data)
+		if !IsSorted(StringSortable(keys)) {
+			t.Errorf("got unsorted keys")
+		}
+		if !reflect.DeepEqual(keys, copies) {
+			t.Errorf("got companion that does not match the keys")
+		}
+		seen := make(map[int]bool)
+		for _, length := range lengths {
+			seen[length] = true
+		}
+		if len(seen) != len(lengths) {
+			t.Errorf("got companion that is no permutation")
+		}
+	}
+}
+
+// TestCoSortableErrors tests that invalid companions are rejected.
+func TestCoSortableErrors(t *testing.T) {
+	tests := map[string]struct {
+		companions []any
+	}{
+		"no_slice": {
+			companions: []any{42},
+		},
+		"nil": {
+			companions: []any{nil},
+		},
+		"other_length": {
+			companions: []any{[]string{"a"}},
+		},
+	}
+	for name, test := range tests {
+		if _, err := NewCoSortable([]int{2, 1}, test.companions...); err == nil {
+			t.Errorf("%s: got no error", name)
+		}
+	}
+}
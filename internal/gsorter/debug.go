@@ -0,0 +1,6 @@
+//go:build debug
+
+package gsorter
+
+// debugBuild enables the checks of VerifyingSorter unconditionally.
+const debugBuild = true
@@ -0,0 +1,190 @@
+package gsorter
+
+import (
+	"math"
+	"sort"
+)
+
+// NaNPolicy specifies where NaN values are placed.
+type NaNPolicy int
+
+const (
+	NaNFirst NaNPolicy = iota // NaNs are less than all other values
+	NaNLast                   // NaNs are greater than all other values
+)
+
+// ZeroPolicy specifies the order of negative and positive zero.
+type ZeroPolicy int
+
+const (
+	ZeroEqual         ZeroPolicy = iota // -0 and +0 are equal
+	NegativeZeroFirst                   // -0 is less than +0
+)
+
+// InfPolicy specifies the order of the infinities.
+type InfPolicy int
+
+const (
+	InfExtremes InfPolicy = iota // -Inf is the least and +Inf the greatest number
+	InfAsNaN                     // infinities are treated like NaNs
+)
+
+// FloatOrder specifies a total order of floating point values. All NaNs are
+// equal to each other, regardless of sign and payload. The zero value is the
+// order that sort.Float64Slice uses.
+type FloatOrder struct {
+	NaN  NaNPolicy
+	Zero ZeroPolicy
+	Inf  InfPolicy
+}
+
+// Float64s returns a sort.Interface for the specified data that sorts it in
+// this order.
+func (o FloatOrder) Float64s(data []float64) sort.Interface {
+	return float64OrderSortable{data, o}
+}
+
+// Float32s returns a sort.Interface for the specified data that sorts it in
+// this order.
+func (o FloatOrder) Float32s(data []float32) sort.Interface {
+	return float32OrderSortable{data, o}
+}
+
+// Float64Sortable is a convenience wrapper for float64 slices that are to be
+// sorted. It uses the zero FloatOrder, so NaNs come first.
+type Float64Sortable []float64
+
+func (a Float64Sortable) Len() int { return len(a) }
+func (a Float64Sortable) Less(i, j int) bool {
+	return float64Key(a[i], FloatOrder{}) < float64Key(a[j], FloatOrder{})
+}
+func (a Float64Sortable) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+
+// Float32Sortable is a convenience wrapper for float32 slices that are to be
+// sorted. It uses the zero FloatOrder, so NaNs come first.
+type Float32Sortable []float32
+
+func (a Float32Sortable) Len() int { return len(a) }
+func (a Float32Sortable) Less(i, j int) bool {
+	return float32Key(a[i], FloatOrder{}) < float32Key(a[j], FloatOrder{})
+}
+func (a Float32Sortable) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+
+// float64OrderSortable sorts float64 values in a configurable order.
+type float64OrderSortable struct {
+	data  []float64
+	order FloatOrder
+}
+
+func (a float64OrderSortable) Len() int { return len(a.data) }
+func (a float64OrderSortable) Less(i, j int) bool {
+	return float64Key(a.data[i], a.order) < float64Key(a.data[j], a.order)
+}
+func (a float64OrderSortable) Swap(i, j int) { a.data[i], a.data[j] = a.data[j], a.data[i] }
+
+// float32OrderSortable sorts float32 values in a configurable order.
+type float32OrderSortable struct {
+	data  []float32
+	order FloatOrder
+}
+
+func (a float32OrderSortable) Len() int { return len(a.data) }
+func (a float32OrderSortable) Less(i, j int) bool {
+	return float32Key(a.data[i], a.order) < float32Key(a.data[j], a.order)
+}
+func (a float32OrderSortable) Swap(i, j int) { a.data[i], a.data[j] = a.data[j], a.data[i] }
+
+// float64Key maps the specified value to an unsigned integer so that the
+// integer order is the specified floating point order. Without the policies,
+// this is the IEEE 754 totalOrder: flipping all bits of negative numbers and
+// only the sign bit of positive numbers makes the bit patterns ascend.
+func float64Key(x float64, order FloatOrder) uint64 {
+	switch {
+	case math.IsNaN(x) || order.Inf == InfAsNaN && math.IsInf(x, 0):
+		if order.NaN == NaNLast {
+			return math.MaxUint64
+		}
+		return 0
+	case x == 0 && order.Zero == ZeroEqual:
+		return 1 << 63
+	}
+	bits := math.Float64bits(x)
+	if bits&(1<<63) != 0 {
+		return ^bits
+	}
+	return bits | 1<<63
+}
+
+// float32Key maps the specified value to an unsigned integer so that the
+// integer order is the specified floating point order. See float64Key.
+func float32Key(x float32, order FloatOrder) uint32 {
+	switch {
+	case x != x || order.Inf == InfAsNaN && math.IsInf(float64(x), 0):
+		if order.NaN == NaNLast {
+			return math.MaxUint32
+		}
+		return 0
+	case x == 0 && order.Zero == ZeroEqual:
+		return 1 << 31
+	}
+	bits := math.Float32bits(x)
+	if bits&(1<<31) != 0 {
+		return ^bits
+	}
+	return bits | 1<<31
+}
+
+// RadixSortFloat64s sorts the specified data in the specified order using a
+// least significant digit radix sort on the integer keys of the values. It
+// runs in linear time, is stable and needs memory for two copies of the data.
+func RadixSortFloat64s(data []float64, order FloatOrder) {
+	keys := make([]uint64, len(data))
+	for i, x := range data {
+		keys[i] = float64Key(x, order)
+	}
+	radixSortKeys(keys, data, 64)
+}
+
+// RadixSortFloat32s sorts the specified data in the specified order using a
+// least significant digit radix sort on the integer keys of the values. It
+// runs in linear time, is stable and needs memory for two copies of the data.
+func RadixSortFloat32s(data []float32, order FloatOrder) {
+	keys := make([]uint64, len(data))
+	for i, x := range data {
+		keys[i] = uint64(float32Key(x, order))
+	}
+	radixSortKeys(keys, data, 32)
+}
+
+// radixSortKeys sorts the specified keys and rearranges the specified values
+// the same way, one byte per pass for the specified number of key bits.
+// Passes in which all keys share the same byte are skipped.
+func radixSortKeys[T any](keys []uint64, values []T, bits int) {
+	result := values
+	keyBuffer := make([]uint64, len(keys))
+	valueBuffer := make([]T, len(values))
+	for shift := 0; shift < bits; shift += 8 {
+		var counts [256]int
+		for _, key := range keys {
+			counts[byte(key>>shift)]++
+		}
+		if len(keys) == 0 || counts[byte(keys[0]>>shift)] == len(keys) {
+			continue
+		}
+		offset := 0
+		for digit, count := range counts {
+			counts[digit] = offset
+			offset += count
+		}
+		for i, key := range keys {
+			digit := byte(key >> shift)
+			keyBuffer[counts[digit]] = key
+			valueBuffer[counts[digit]] = values[i]
+			counts[digit]++
+		}
+		keys, keyBuffer = keyBuffer, keys
+		values, valueBuffer = valueBuffer, values
+	}
+	// After an odd number of passes the values are in the buffer.
+	copy(result, values)
+}
@@ -0,0 +1,156 @@
+package gsorter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestFloatOrder tests all sort functions and the radix sort with float64 and
+// float32 values in different orders. Results are compared by their keys,
+// because NaNs are never equal and equal zeros may have different signs.
+func TestFloatOrder(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	negZero := math.Copysign(0, -1)
+	input := []float64{3, nan, -inf, 0, -1.5, inf, negZero, nan, 2}
+	tests := map[string]struct {
+		order FloatOrder
+		want  []float64
+	}{
+		"default": {
+			order: FloatOrder{},
+			want:  []float64{nan, nan, -inf, -1.5, 0, 0, 2, 3, inf},
+		},
+		"nan_last": {
+			order: FloatOrder{NaN: NaNLast},
+			want:  []float64{-inf, -1.5, 0, 0, 2, 3, inf, nan, nan},
+		},
+		"negative_zero_first": {
+			order: FloatOrder{Zero: NegativeZeroFirst},
+			want:  []float64{nan, nan, -inf, -1.5, negZero, 0, 2, 3, inf},
+		},
+		"inf_as_nan": {
+			order: FloatOrder{Inf: InfAsNaN},
+			want:  []float64{nan, nan, nan, nan, -1.5, 0, 0, 2, 3},
+		},
+		"all_policies": {
+			order: FloatOrder{NaN: NaNLast, Zero: NegativeZeroFirst, Inf: InfAsNaN},
+			want:  []float64{-1.5, negZero, 0, 2, 3, nan, nan, nan, nan},
+		},
+	}
+	for name, test := range tests {
+		for _, sortFunction := range SortFunctions {
+			slice := append([]float64{}, input...)
+			sortFunction(test.order.Float64s(slice))
+			if !sameFloat64Keys(slice, test.want, test.order) {
+				t.Errorf("%s: got %v but want %v", name, slice, test.want)
+			}
+
+			slice32 := toFloat32s(input)
+			sortFunction(test.order.Float32s(slice32))
+			if !sameFloat32Keys(slice32, toFloat32s(test.want), test.order) {
+				t.Errorf("%s: got %v but want %v", name, slice32, test.want)
+			}
+		}
+
+		slice := append([]float64{}, input...)
+		RadixSortFloat64s(slice, test.order)
+		if !sameFloat64Keys(slice, test.want, test.order) {
+			t.Errorf("%s: radix sort got %v but want %v", name, slice, test.want)
+		}
+		slice32 := toFloat32s(input)
+		RadixSortFloat32s(slice32, test.order)
+		if !sameFloat32Keys(slice32, toFloat32s(test.want), test.order) {
+			t.Errorf("%s: radix sort got %v but want %v", name, slice32, test.want)
+		}
+	}
+}
+
+// TestFloatSortable tests all sort functions with the default wrappers.
+func TestFloatSortable(t *testing.T) {
+	nan := math.NaN()
+	for _, sortFunction := range SortFunctions {
+		slice := []float64{2, nan, -1, nan, 0.5}
+		sortFunction(Float64Sortable(slice))
+		if !sameFloat64Keys(slice, []float64{nan, nan, -1, 0.5, 2}, FloatOrder{}) {
+			t.Errorf("got %v", slice)
+		}
+		slice32 := []float32{2, float32(nan), -1, 0.5}
+		sortFunction(Float32Sortable(slice32))
+		if !sameFloat32Keys(slice32, []float32{float32(nan), -1, 0.5, 2}, FloatOrder{}) {
+			t.Errorf("got %v", slice32)
+		}
+	}
+}
+
+// TestLargeNaNSlice tests that all sort functions and the radix sort
+// terminate and produce a total order on large input with many NaNs. The size
+// is large enough for GoroutineSort to use goroutines.
+func TestLargeNaNSlice(t *testing.T) {
+	for _, order := range []FloatOrder{{NaN: NaNFirst}, {NaN: NaNLast}} {
+		original := make([]float64, 20000)
+		for i := range original {
+			switch rand.Intn(4) {
+			case 0:
+				original[i] = math.NaN()
+			case 1:
+				original[i] = math.Copysign(0, float64(rand.Intn(2)*2-1))
+			default:
+				original[i] = rand.NormFloat64()
+			}
+		}
+		sortFunctions := []func([]float64){
+			func(data []float64) { QuickSort(order.Float64s(data)) },
+			func(data []float64) { GoroutineSort(order.Float64s(data)) },
+			func(data []float64) { RadixSortFloat64s(data, order) },
+		}
+		for i, sortFunction := range sortFunctions {
+			slice := append([]float64{}, original...)
+			sortFunction(slice)
+			if !IsSorted(order.Float64s(slice)) {
+				t.Errorf("sort function %d with %v: got unsorted data", i, order)
+			}
+			if order.NaN == NaNFirst && !math.IsNaN(slice[0]) ||
+				order.NaN == NaNLast && !math.IsNaN(slice[len(slice)-1]) {
+				t.Errorf("sort function %d with %v: got NaNs at the wrong end", i, order)
+			}
+		}
+	}
+}
+
+// sameFloat64Keys reports whether the specified slices are equal in the
+// specified order.
+func sameFloat64Keys(got []float64, want []float64, order FloatOrder) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if float64Key(got[i], order) != float64Key(want[i], order) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameFloat32Keys reports whether the specified slices are equal in the
+// specified order.
+func sameFloat32Keys(got []float32, want []float32, order FloatOrder) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if float32Key(got[i], order) != float32Key(want[i], order) {
+			return false
+		}
+	}
+	return true
+}
+
+// toFloat32s converts the specified values to float32.
+func toFloat32s(input []float64) []float32 {
+	result := make([]float32, len(input))
+	for i, x := range input {
+		result[i] = float32(x)
+	}
+	return result
+}
@@ -22,10 +22,11 @@ func (a StringSortable) Less(i, j int) bool { return a[i] < a[j] }
 func (a StringSortable) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
 // TimeSortable is a convenience wrapper for time.Time slices that are to be sorted.
+// It orders by instant and supports the full range of time.Time.
 type TimeSortable []time.Time
 
 func (a TimeSortable) Len() int           { return len(a) }
-func (a TimeSortable) Less(i, j int) bool { return a[i].UnixNano() < a[j].UnixNano() }
+func (a TimeSortable) Less(i, j int) bool { return a[i].Before(a[j]) }
 func (a TimeSortable) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
 // SortFunctions is a slice of all sort functions implemented in this package.
@@ -139,6 +140,37 @@ func parallelSort(data sort.Interface, from int, to int, wg *sync.WaitGroup) {
 	goroutineSortRange(data, from, to)
 }
 
+// Distribution is a named input pattern for sort functions.
+type Distribution struct {
+	Name string
+
+	// Arrange rearranges the specified random data so that it follows the
+	// input pattern.
+	Arrange func(data sort.Interface)
+}
+
+// Distributions is a slice of all input patterns that sort functions are
+// benchmarked with.
+var Distributions = []Distribution{
+	{"random", func(data sort.Interface) {}},
+	{"sorted", sort.Sort},
+	{"reversed", func(data sort.Interface) { sort.Sort(sort.Reverse(data)) }},
+	{"nearly_sorted", arrangeNearlySorted},
+}
+
+// arrangeNearlySorted sorts the specified data and then swaps one percent of
+// the elements with random other elements.
+func arrangeNearlySorted(data sort.Interface) {
+	sort.Sort(data)
+	length := data.Len()
+	if length < 2 {
+		return
+	}
+	for i := 0; i <= length/100; i++ {
+		data.Swap(rand.Intn(length), rand.Intn(length))
+	}
+}
+
 // CreateRandomInts returns a slice of the specified size that consists of
 // random positive int values.
 func CreateRandomInts(size int) []int {
@@ -149,6 +181,17 @@ func CreateRandomInts(size int) []int {
 	return result
 }
 
+// CreateSkewedInts returns a slice of the specified size that consists of
+// random non-negative int values whose magnitudes are evenly distributed, so
+// that small values are much more frequent than large ones.
+func CreateSkewedInts(size int) []int {
+	result := make([]int, size)
+	for i := 0; i < size; i++ {
+		result[i] = int(rand.Int63() >> rand.Intn(63))
+	}
+	return result
+}
+
 // CreateRandomStrings returns a slice of the specified size that consists of
 // random strings of the specified length.
 func CreateRandomStrings(size int, length int) []string {
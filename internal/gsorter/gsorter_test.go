@@ -1,8 +1,12 @@
 package gsorter
 
 import (
+	"fmt"
 	"reflect"
+	"runtime"
+	"slices"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 )
@@ -323,3 +327,167 @@ func TestSplitUsingPivot(t *testing.T) {
 		}
 	}
 }
+
+// TestDistributions tests that all distributions only rearrange the data.
+func TestDistributions(t *testing.T) {
+	for _, distribution := range Distributions {
+		slice := CreateRandomInts(1000)
+		want := make([]int, len(slice))
+		copy(want, slice)
+		sort.Ints(want)
+		distribution.Arrange(IntSortable(slice))
+		if distribution.Name == "sorted" && !sort.IntsAreSorted(slice) {
+			t.Errorf("%s: got unsorted data", distribution.Name)
+		}
+		sort.Ints(slice)
+		if !reflect.DeepEqual(slice, want) {
+			t.Errorf("%s: got other elements than the input", distribution.Name)
+		}
+	}
+}
+
+// benchmarkSizes are the numbers of elements that the sort functions are
+// benchmarked with.
+var benchmarkSizes = []int{100, 1000, 10000}
+
+// benchmarkTypes are the element types that the sort functions are
+// benchmarked with. The create function returns random original data, a
+// slice of the same size to be sorted and a function that copies the
+// original data into that slice.
+var benchmarkTypes = []struct {
+	name   string
+	create func(size int) (original sort.Interface, data sort.Interface, reset func())
+}{
+	{"int", func(size int) (sort.Interface, sort.Interface, func()) {
+		original, data := IntSortable(CreateRandomInts(size)), make(IntSortable, size)
+		return original, data, func() { copy(data, original) }
+	}},
+	{"string", func(size int) (sort.Interface, sort.Interface, func()) {
+		original, data := StringSortable(CreateRandomStrings(size, 10)), make(StringSortable, size)
+		return original, data, func() { copy(data, original) }
+	}},
+	{"time", func(size int) (sort.Interface, sort.Interface, func()) {
+		original, data := TimeSortable(CreateRandomTimes(size)), make(TimeSortable, size)
+		return original, data, func() { copy(data, original) }
+	}},
+}
+
+// BenchmarkSort benchmarks all sort functions with all element types,
+// distributions and sizes. The sub-benchmark names use the key=value form
+// that benchstat can group by, for example:
+//
+//	go test -run=^$ -bench=Sort -count=10 ./internal/gsorter > new.txt
+//	benchstat -col /algorithm -filter /type:string new.txt
+func BenchmarkSort(b *testing.B) {
+	for _, sortFunction := range SortFunctions {
+		name := functionName(sortFunction)
+		for _, elementType := range benchmarkTypes {
+			for _, distribution := range Distributions {
+				for _, size := range benchmarkSizes {
+
+					// Bubble sort is too slow on large lists.
+					if name == "BubbleSort" && size > 1000 {
+						continue
+					}
+
+					benchmarkName := fmt.Sprintf("algorithm=%s/type=%s/dist=%s/n=%d",
+						name, elementType.name, distribution.Name, size)
+					b.Run(benchmarkName, func(b *testing.B) {
+						original, data, reset := elementType.create(size)
+						distribution.Arrange(original)
+						b.ReportAllocs()
+						b.ResetTimer()
+						for i := 0; i < b.N; i++ {
+							// Copying is linear and cheap compared to sorting,
+							// so it is measured rather than paying for StopTimer.
+							reset()
+							sortFunction(data)
+						}
+						b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/float64(size), "ns/element")
+					})
+				}
+			}
+		}
+	}
+}
+
+// functionName returns the name of the specified function without the path
+// and name of this package.
+func functionName(function any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(function).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return strings.TrimPrefix(name, "gsorter.")
+}
+
+// FuzzBubbleSort checks the BubbleSort function with fuzzed input.
+func FuzzBubbleSort(f *testing.F) {
+	fuzzSort(f, BubbleSort, true)
+}
+
+// FuzzQuickSort checks the QuickSort function with fuzzed input.
+func FuzzQuickSort(f *testing.F) {
+	fuzzSort(f, QuickSort, false)
+}
+
+// FuzzGoroutineSort checks the GoroutineSort function with fuzzed input.
+func FuzzGoroutineSort(f *testing.F) {
+	fuzzSort(f, GoroutineSort, false)
+}
+
+// record is an element with a key to sort by and its original position.
+type record struct {
+	key   int
+	index int
+}
+
+// recordsByKey sorts records by key only, so that the original positions
+// reveal whether a sort function is stable.
+type recordsByKey []record
+
+func (a recordsByKey) Len() int           { return len(a) }
+func (a recordsByKey) Less(i, j int) bool { return a[i].key < a[j].key }
+func (a recordsByKey) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+// fuzzSort checks that the specified sort function returns a sorted
+// permutation of the fuzzed input that is identical to the result of
+// slices.Sort. If stable is true, it also checks that equal elements keep
+// their order. Every input byte is taken as a signed value, so that negative
+// numbers and duplicates are frequent.
+func fuzzSort(f *testing.F, sortFunction func(sort.Interface), stable bool) {
+	f.Fuzz(func(t *testing.T, input []byte) {
+		records := make(recordsByKey, len(input))
+		for i, b := range input {
+			records[i] = record{key: int(int8(b)), index: i}
+		}
+		sortFunction(records)
+
+		if !sort.IsSorted(records) {
+			t.Fatalf("got unsorted %v", records)
+		}
+		seen := make([]bool, len(input))
+		for _, r := range records {
+			if seen[r.index] || r.key != int(int8(input[r.index])) {
+				t.Fatalf("got %v which is no permutation of %v", records, input)
+			}
+			seen[r.index] = true
+		}
+		for i := 1; stable && i < len(records); i++ {
+			if records[i-1].key == records[i].key && records[i-1].index > records[i].index {
+				t.Fatalf("got unstable order at index %d: %v", i, records)
+			}
+		}
+
+		got := make([]int, len(records))
+		for i, r := range records {
+			got[i] = r.key
+		}
+		want := make([]int, len(input))
+		for i, b := range input {
+			want[i] = int(int8(b))
+		}
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Fatalf("got %v but want %v", got, want)
+		}
+	})
+}
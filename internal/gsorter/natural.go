@@ -0,0 +1,77 @@
+package gsorter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// NaturalStringSortable is a convenience wrapper for string slices that are
+// to be sorted in natural order, see CompareNatural.
+type NaturalStringSortable []string
+
+func (a NaturalStringSortable) Len() int           { return len(a) }
+func (a NaturalStringSortable) Less(i, j int) bool { return CompareNatural(a[i], a[j]) < 0 }
+func (a NaturalStringSortable) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+// NaturalBy returns a comparator that orders values by the specified string
+// key in natural order. A nil key function treats all values as equal.
+func NaturalBy[T any](key func(T) string) Comparator[T] {
+	return ByFunc(key, CompareNatural)
+}
+
+// CompareNatural compares the specified strings in natural order, which is
+// how people sort file names: runs of the digits 0 to 9 are compared by their
+// numeric value, so "file2" comes before "file10", and all other characters
+// are compared rune by rune. Digit runs of any length are supported. Dotted
+// numbers are compared part by part, like versions, so "1.9" comes before
+// "1.10". Numbers that differ only in leading zeros are ordered by the number
+// of zeros, fewest first, if the strings are otherwise equal. The result is
+// a negative number, zero or a positive number like strings.Compare, and
+// zero only if the strings are equal.
+func CompareNatural(a, b string) int {
+	zeros := 0 // the first difference in leading zeros
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			digitsA, digitsB := digitRun(a), digitRun(b)
+			a, b = a[len(digitsA):], b[len(digitsB):]
+			valueA, valueB := strings.TrimLeft(digitsA, "0"), strings.TrimLeft(digitsB, "0")
+			if len(valueA) != len(valueB) {
+				return len(valueA) - len(valueB)
+			}
+			if result := strings.Compare(valueA, valueB); result != 0 {
+				return result
+			}
+			if zeros == 0 {
+				zeros = len(digitsA) - len(digitsB)
+			}
+			continue
+		}
+		runeA, sizeA := utf8.DecodeRuneInString(a)
+		runeB, sizeB := utf8.DecodeRuneInString(b)
+		if runeA != runeB {
+			return int(runeA) - int(runeB)
+		}
+		if result := strings.Compare(a[:sizeA], b[:sizeB]); result != 0 {
+			return result // different invalid UTF-8 bytes
+		}
+		a, b = a[sizeA:], b[sizeB:]
+	}
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return zeros
+}
+
+// isDigit reports whether the specified byte is one of the digits 0 to 9.
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// digitRun returns the digits at the start of the specified string.
+func digitRun(s string) string {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i]
+}
@@ -0,0 +1,147 @@
+package gsorter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestNaturalSort tests all sort functions with corpora of real-world file
+// names in natural order.
+func TestNaturalSort(t *testing.T) {
+	tests := map[string][]string{
+		"photos": {
+			"IMG_0009.JPG",
+			"IMG_0010.JPG",
+			"IMG_0099.JPG",
+			"IMG_0100.JPG",
+			"IMG_1000 (1).JPG",
+			"IMG_1000 (2).JPG",
+			"IMG_1000 (10).JPG",
+			"IMG_1000.JPG",
+		},
+		"chapters": {
+			"Chapter 1.pdf",
+			"Chapter 2.pdf",
+			"Chapter 9.pdf",
+			"Chapter 10.pdf",
+			"Chapter 11.pdf",
+			"Chapter 20 - Appendix.pdf",
+			"Chapter 100.pdf",
+		},
+		"leading_zeros": {
+			"track1.mp3",
+			"track01.mp3",
+			"track001.mp3",
+			"track2.mp3",
+			"track02.mp3",
+			"track10.mp3",
+		},
+		"leading_zeros_first_difference": {
+			"a1b01",
+			"a01b1",
+			"a01b01",
+		},
+		"releases": {
+			"go1.9.7.linux-amd64.tar.gz",
+			"go1.10.1.linux-amd64.tar.gz",
+			"go1.10.linux-amd64.tar.gz",
+			"go1.21.0.linux-amd64.tar.gz",
+			"linux-4.19.306.tar.xz",
+			"linux-5.4.9.tar.xz",
+			"linux-5.4.10.tar.xz",
+			"linux-5.10.1.tar.xz",
+			"linux-6.1.tar.xz",
+		},
+		"logs": {
+			"app.log",
+			"app.log.1",
+			"app.log.2",
+			"app.log.10",
+			"app.log.10.gz",
+			"app.log.11.gz",
+		},
+		"long_digit_runs": {
+			"id 9",
+			"id 18446744073709551615",
+			"id 18446744073709551616",
+			"id 99999999999999999999999999999999",
+			"id 100000000000000000000000000000000",
+			"id 100000000000000000000000000000000a",
+		},
+		"unicode": {
+			"Résumé 2.docx",
+			"Résumé 10.docx",
+			"résumé 1.docx",
+			"Übung 3.txt",
+			"Übung 12.txt",
+			"写真 2.png",
+			"写真 11.png",
+		},
+		"digits_and_punctuation": {
+			"",
+			"-1",
+			"0",
+			"1",
+			"1-2",
+			"1.5",
+			"1.25",
+			"a",
+		},
+	}
+	for name, want := range tests {
+		for _, sortFunction := range SortFunctions {
+			slice := shuffledStrings(want)
+			sortFunction(NaturalStringSortable(slice))
+			if !reflect.DeepEqual(slice, want) {
+				t.Errorf("%s: got %q but want %q", name, slice, want)
+			}
+		}
+	}
+}
+
+// TestCompareNatural tests that the natural order is a consistent total
+// order on all pairs of strings of the corpus.
+func TestCompareNatural(t *testing.T) {
+	corpus := []string{
+		"", "0", "00", "000", "1", "01", "10", "010", "a", "a0", "a00", "a1", "a01",
+		"a1b", "a01b", "a1b1", "a1b01", "a01b1", "1.2", "1.02", "1.10", "\xff", "\xfe", "ä1",
+		strings.Repeat("9", 40), strings.Repeat("0", 40) + "1",
+	}
+	for _, a := range corpus {
+		for _, b := range corpus {
+			got := sign(CompareNatural(a, b))
+			if got != -sign(CompareNatural(b, a)) {
+				t.Errorf("%q, %q: comparison is not antisymmetric", a, b)
+			}
+			if (got == 0) != (a == b) {
+				t.Errorf("%q, %q: got %v", a, b, got)
+			}
+			for _, c := range corpus {
+				if got <= 0 && sign(CompareNatural(b, c)) <= 0 && CompareNatural(a, c) > 0 {
+					t.Errorf("%q, %q, %q: comparison is not transitive", a, b, c)
+				}
+			}
+		}
+	}
+}
+
+// TestNaturalBy tests the natural comparator with a key function.
+func TestNaturalBy(t *testing.T) {
+	type file struct {
+		name string
+		size int
+	}
+	want := []file{{"v2", 1}, {"v10", 3}, {"v10", 2}, {"v100", 0}}
+	comparator := NaturalBy(func(f file) string { return f.name }).ThenDesc(By(func(f file) int { return f.size }))
+	for _, sortFunction := range SortFunctions {
+		slice := append([]file{}, want...)
+		for i, j := 0, len(slice)-1; i < j; i, j = i+1, j-1 {
+			slice[i], slice[j] = slice[j], slice[i]
+		}
+		sortFunction(comparator.Sortable(slice))
+		if !reflect.DeepEqual(slice, want) {
+			t.Errorf("got %v but want %v", slice, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package gsorter
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// compositionPairs is the inverse of decompositionPairs.
+var compositionPairs = make(map[[2]rune]rune, len(decompositionPairs))
+
+func init() {
+	for composite, pair := range decompositionPairs {
+		compositionPairs[pair] = composite
+	}
+}
+
+// NFD returns the canonical decomposition of the specified string, in which
+// precomposed letters are replaced by a base letter and combining marks.
+// Only the Latin letters of decompositionPairs are decomposed; other
+// characters are kept as they are.
+func NFD(s string) string {
+	return string(decompose(s))
+}
+
+// NFC returns the canonical composition of the specified string, in which
+// base letters and combining marks are replaced by precomposed letters
+// wherever possible. Only the Latin letters of decompositionPairs are
+// composed; other characters are kept as they are.
+func NFC(s string) string {
+	decomposed := decompose(s)
+	composed := decomposed[:0]
+	starter := -1   // index of the last starter in composed or -1
+	lastClass := -1 // combining class of the last mark after the starter
+	for _, r := range decomposed {
+		class := int(combiningClasses[r])
+		if starter >= 0 && lastClass < class {
+			if composite, ok := compositionPairs[[2]rune{composed[starter], r}]; ok {
+				composed[starter] = composite
+				continue
+			}
+		}
+		if class == 0 {
+			starter = len(composed)
+			lastClass = -1
+		} else {
+			lastClass = class
+		}
+		composed = append(composed, r)
+	}
+	return string(composed)
+}
+
+// decompose returns the runes of the canonical decomposition of the specified
+// string, with combining marks in canonical order.
+func decompose(s string) []rune {
+	result := make([]rune, 0, utf8.RuneCountInString(s))
+	for _, r := range s {
+		result = appendDecomposed(result, r)
+	}
+
+	// Sort every run of combining marks by class, keeping the order of marks
+	// of the same class.
+	for start := 0; start < len(result); {
+		if combiningClasses[result[start]] == 0 {
+			start++
+			continue
+		}
+		end := start
+		for end < len(result) && combiningClasses[result[end]] != 0 {
+			end++
+		}
+		marks := result[start:end]
+		sort.SliceStable(marks, func(i, j int) bool {
+			return combiningClasses[marks[i]] < combiningClasses[marks[j]]
+		})
+		start = end
+	}
+	return result
+}
+
+// appendDecomposed appends the full canonical decomposition of the specified
+// rune to the specified runes.
+func appendDecomposed(runes []rune, r rune) []rune {
+	pair, ok := decompositionPairs[r]
+	if !ok {
+		return append(runes, r)
+	}
+	runes = appendDecomposed(runes, pair[0])
+	return appendDecomposed(runes, pair[1])
+}
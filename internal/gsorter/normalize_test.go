@@ -0,0 +1,40 @@
+package gsorter
+
+import "testing"
+
+// TestNormalize tests the canonical composition and decomposition.
+func TestNormalize(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		wantNFC string
+		wantNFD string
+	}{
+		"ascii":        {"abc", "abc", "abc"},
+		"precomposed":  {"\u00e9", "\u00e9", "e\u0301"},
+		"decomposed":   {"e\u0301", "\u00e9", "e\u0301"},
+		"two_marks":    {"\u1ec7", "\u1ec7", "e\u0323\u0302"},
+		"mark_order":   {"e\u0302\u0323", "\u1ec7", "e\u0323\u0302"},
+		"blocked":      {"a\u0301\u0301", "\u00e1\u0301", "a\u0301\u0301"},
+		"no_base":      {"\u0301a", "\u0301a", "\u0301a"},
+		"other_script": {"日本", "日本", "日本"},
+		"mixed":        {"Mu\u0308ller-\u00c5", "M\u00fcller-\u00c5", "Mu\u0308ller-A\u030a"},
+	}
+	for name, test := range tests {
+		if got := NFC(test.input); got != test.wantNFC {
+			t.Errorf("%s: NFC got %q but want %q", name, got, test.wantNFC)
+		}
+		if got := NFD(test.input); got != test.wantNFD {
+			t.Errorf("%s: NFD got %q but want %q", name, got, test.wantNFD)
+		}
+	}
+}
+
+// TestNormalizeTables tests that every decomposition in the tables composes
+// back to its character.
+func TestNormalizeTables(t *testing.T) {
+	for r := range decompositionPairs {
+		if got := NFC(NFD(string(r))); got != string(r) {
+			t.Errorf("%U: got %q but want %q", r, got, string(r))
+		}
+	}
+}
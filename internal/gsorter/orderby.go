@@ -0,0 +1,236 @@
+package gsorter
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned by OrderBy. They are wrapped with details about the spec.
+var (
+	ErrInvalidSpec   = errors.New("invalid order-by spec")
+	ErrUnknownField  = errors.New("unknown field")
+	ErrIncomparable  = errors.New("incomparable field")
+	ErrNotStructData = errors.New("data is no slice of structs")
+)
+
+// timeType is the type of time.Time, which is compared with its Compare
+// method.
+var timeType = reflect.TypeOf(time.Time{})
+
+// orderKeyCache caches the parsed keys per element type and spec, so that
+// the fields are only resolved once.
+var orderKeyCache sync.Map // orderKeyCacheKey -> []orderKey
+
+// orderKeyCacheKey is the key of orderKeyCache.
+type orderKeyCacheKey struct {
+	elementType reflect.Type
+	spec        string
+}
+
+// orderKey is a resolved field to sort by.
+type orderKey struct {
+	indexes    [][]int // field indexes per path segment, see reflect.Value.FieldByIndex
+	compare    func(a, b reflect.Value) int
+	descending bool
+}
+
+// OrderBy returns a sort.Interface that sorts the specified slice of structs
+// or pointers to structs by the fields named in the specified spec. The spec
+// is a comma-separated list of field paths, each optionally followed by
+// "asc" or "desc", for example "Address.City desc, Age". A path segment
+// names an exported field or a field with a matching `sort:"name"` tag.
+// Paths may go through pointers and embedded structs. A nil pointer on the
+// way compares less than all values, so it sorts first in ascending and last
+// in descending order. Fields must be of a numeric, string or bool kind or of
+// type time.Time. The result can be used with all SortFunctions.
+func OrderBy(data any, spec string) (sort.Interface, error) {
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("%w: got %T", ErrNotStructData, data)
+	}
+	keys, err := orderKeys(value.Type().Elem(), spec)
+	if err != nil {
+		return nil, err
+	}
+	return reflectSortable{value, reflect.Swapper(data), keys}, nil
+}
+
+// orderKeys returns the cached keys of the specified element type and spec or
+// resolves them.
+func orderKeys(elementType reflect.Type, spec string) ([]orderKey, error) {
+	cacheKey := orderKeyCacheKey{elementType, spec}
+	if keys, ok := orderKeyCache.Load(cacheKey); ok {
+		return keys.([]orderKey), nil
+	}
+	structType := elementType
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: got elements of type %v", ErrNotStructData, elementType)
+	}
+
+	var keys []orderKey
+	for _, term := range strings.Split(spec, ",") {
+		words := strings.Fields(term)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, fmt.Errorf("%w: %q must be a field path and an optional direction", ErrInvalidSpec, term)
+		}
+		var key orderKey
+		if len(words) == 2 {
+			switch strings.ToLower(words[1]) {
+			case "asc":
+			case "desc":
+				key.descending = true
+			default:
+				return nil, fmt.Errorf("%w: unknown direction %q, want asc or desc", ErrInvalidSpec, words[1])
+			}
+		}
+		fieldType := structType
+		for _, name := range strings.Split(words[0], ".") {
+			if name == "" {
+				return nil, fmt.Errorf("%w: %q has an empty path segment", ErrInvalidSpec, words[0])
+			}
+			for fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("%w: cannot select %q of %q in type %v, which is no struct",
+					ErrUnknownField, name, words[0], fieldType)
+			}
+			field, ok := lookupField(fieldType, name)
+			if !ok {
+				return nil, fmt.Errorf("%w: no exported field or tag %q of %q in type %v",
+					ErrUnknownField, name, words[0], fieldType)
+			}
+			key.indexes = append(key.indexes, field.Index)
+			fieldType = field.Type
+		}
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		key.compare = compareFunction(fieldType)
+		if key.compare == nil {
+			return nil, fmt.Errorf("%w: %q has type %v, want a number, string, bool or time.Time",
+				ErrIncomparable, words[0], fieldType)
+		}
+		keys = append(keys, key)
+	}
+	orderKeyCache.Store(cacheKey, keys)
+	return keys, nil
+}
+
+// lookupField returns the exported field of the specified struct type that
+// has a matching sort tag or, failing that, the specified name. Fields of
+// embedded structs are found as well. An empty name matches no field, not
+// even the untagged ones.
+func lookupField(structType reflect.Type, name string) (reflect.StructField, bool) {
+	if name == "" {
+		return reflect.StructField{}, false
+	}
+	for _, field := range reflect.VisibleFields(structType) {
+		if field.IsExported() && field.Tag.Get("sort") == name {
+			return field, true
+		}
+	}
+	field, ok := structType.FieldByName(name)
+	if !ok || !field.IsExported() {
+		return reflect.StructField{}, false
+	}
+	return field, true
+}
+
+// compareFunction returns a function that compares two values of the
+// specified type or nil if the type cannot be compared.
+func compareFunction(fieldType reflect.Type) func(a, b reflect.Value) int {
+	if fieldType == timeType {
+		return func(a, b reflect.Value) int {
+			return a.Interface().(time.Time).Compare(b.Interface().(time.Time))
+		}
+	}
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b reflect.Value) int { return cmp.Compare(a.Int(), b.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b reflect.Value) int { return cmp.Compare(a.Uint(), b.Uint()) }
+	case reflect.Float32, reflect.Float64:
+		return func(a, b reflect.Value) int { return cmp.Compare(a.Float(), b.Float()) }
+	case reflect.String:
+		return func(a, b reflect.Value) int { return cmp.Compare(a.String(), b.String()) }
+	case reflect.Bool:
+		return func(a, b reflect.Value) int {
+			switch {
+			case a.Bool() == b.Bool():
+				return 0
+			case b.Bool():
+				return -1
+			}
+			return 1
+		}
+	}
+	return nil
+}
+
+// value returns the field of the specified struct value that the key refers
+// to. The boolean result is false if there is a nil pointer on the way.
+func (k orderKey) value(v reflect.Value) (reflect.Value, bool) {
+	for _, index := range k.indexes {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return v, false
+			}
+			v = v.Elem()
+		}
+		var err error
+		if v, err = v.FieldByIndexErr(index); err != nil {
+			return v, false // nil pointer to an embedded struct
+		}
+	}
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// reflectSortable sorts a slice of structs by the fields of order keys.
+type reflectSortable struct {
+	data reflect.Value
+	swap func(i, j int)
+	keys []orderKey
+}
+
+func (a reflectSortable) Len() int { return a.data.Len() }
+func (a reflectSortable) Less(i, j int) bool {
+	for _, key := range a.keys {
+		valueI, okI := key.value(a.data.Index(i))
+		valueJ, okJ := key.value(a.data.Index(j))
+		var result int
+		switch {
+		case !okI && !okJ:
+			result = 0
+		case !okI:
+			result = -1
+		case !okJ:
+			result = 1
+		default:
+			result = key.compare(valueI, valueJ)
+		}
+		if key.descending {
+			result = -result
+		}
+		if result != 0 {
+			return result < 0
+		}
+	}
+	return false
+}
+func (a reflectSortable) Swap(i, j int) { a.swap(i, j) }
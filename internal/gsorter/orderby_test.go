@@ -0,0 +1,212 @@
+package gsorter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// address is a nested struct for the OrderBy tests.
+type address struct {
+	City string
+	Zip  *int
+}
+
+// Contact is an embedded struct for the OrderBy tests.
+type Contact struct {
+	Email string `sort:"mail"`
+}
+
+// person is a struct with nested, pointer and embedded fields for the
+// OrderBy tests.
+type person struct {
+	Name    string
+	Age     int
+	Born    time.Time
+	Tags    []string
+	Address *address
+	Contact
+	secret int
+}
+
+// TestOrderBy tests all sort functions with order-by specs.
+func TestOrderBy(t *testing.T) {
+	zip1, zip2 := 10115, 80331
+	alice := person{Name: "Alice", Age: 30, Address: &address{"Munich", &zip2}, Contact: Contact{"a@x"}}
+	bob := person{Name: "Bob", Age: 25, Address: &address{"Berlin", &zip1}, Contact: Contact{"c@x"}}
+	carol := person{Name: "Carol", Age: 35, Address: &address{"Munich", nil}, Contact: Contact{"b@x"}}
+	dave := person{Name: "Dave", Age: 25, Address: nil, Contact: Contact{"d@x"}}
+	input := []person{carol, dave, alice, bob}
+
+	tests := map[string]struct {
+		spec string
+		want []person
+	}{
+		"single_field": {
+			spec: "Name",
+			want: []person{alice, bob, carol, dave},
+		},
+		"nested_desc_then_age": {
+			spec: "Address.City desc, Age",
+			want: []person{alice, carol, bob, dave},
+		},
+		"age_then_name_desc": {
+			spec: " Age asc ,Name DESC ",
+			want: []person{dave, bob, alice, carol},
+		},
+		"nested_pointer": {
+			spec: "Address.Zip, Name desc",
+			want: []person{dave, carol, bob, alice},
+		},
+		"nil_first_asc": {
+			spec: "Address.City, Name",
+			want: []person{dave, bob, alice, carol},
+		},
+		"nil_last_desc": {
+			spec: "Address.City desc, Name",
+			want: []person{alice, carol, bob, dave},
+		},
+		"nested_nil_pointer_desc": {
+			spec: "Address.Zip desc, Name",
+			want: []person{alice, bob, carol, dave},
+		},
+		"embedded_by_tag": {
+			spec: "mail",
+			want: []person{alice, carol, bob, dave},
+		},
+		"embedded_by_path": {
+			spec: "Contact.Email desc",
+			want: []person{dave, bob, carol, alice},
+		},
+	}
+	for name, test := range tests {
+		for _, sortFunction := range SortFunctions {
+			slice := append([]person{}, input...)
+			data, err := OrderBy(slice, test.spec)
+			if err != nil {
+				t.Fatalf("%s: got error %v", name, err)
+			}
+			sortFunction(data)
+			if !reflect.DeepEqual(slice, test.want) {
+				t.Errorf("%s: got %v but want %v", name, names(slice), names(test.want))
+			}
+		}
+	}
+}
+
+// TestOrderByPointers tests OrderBy with a slice of pointers to structs.
+func TestOrderByPointers(t *testing.T) {
+	early := &person{Name: "early", Born: time.Date(1500, time.May, 1, 0, 0, 0, 0, time.UTC)}
+	late := &person{Name: "late", Born: time.Date(2500, time.May, 1, 0, 0, 0, 0, time.UTC)}
+	slice := []*person{late, early}
+	data, err := OrderBy(slice, "Born")
+	if err != nil {
+		t.Fatal(err)
+	}
+	QuickSort(data)
+	if slice[0] != early || slice[1] != late {
+		t.Errorf("got %v, %v but want early, late", slice[0].Name, slice[1].Name)
+	}
+}
+
+// TestOrderByErrors tests that OrderBy reports invalid specs and data.
+func TestOrderByErrors(t *testing.T) {
+	tests := map[string]struct {
+		data any
+		spec string
+		want error
+	}{
+		"empty_spec": {
+			data: []person{},
+			spec: "",
+			want: ErrInvalidSpec,
+		},
+		"empty_term": {
+			data: []person{},
+			spec: "Name,",
+			want: ErrInvalidSpec,
+		},
+		"leading_dot": {
+			data: []person{},
+			spec: ".Age",
+			want: ErrInvalidSpec,
+		},
+		"double_dot": {
+			data: []person{},
+			spec: "Address..City",
+			want: ErrInvalidSpec,
+		},
+		"trailing_dot": {
+			data: []person{},
+			spec: "Address.",
+			want: ErrInvalidSpec,
+		},
+		"unknown_direction": {
+			data: []person{},
+			spec: "Name up",
+			want: ErrInvalidSpec,
+		},
+		"unknown_field": {
+			data: []person{},
+			spec: "Address.Town",
+			want: ErrUnknownField,
+		},
+		"unexported_field": {
+			data: []person{},
+			spec: "secret",
+			want: ErrUnknownField,
+		},
+		"path_through_non_struct": {
+			data: []person{},
+			spec: "Name.Length",
+			want: ErrUnknownField,
+		},
+		"struct_field": {
+			data: []person{},
+			spec: "Address",
+			want: ErrIncomparable,
+		},
+		"slice_field": {
+			data: []person{},
+			spec: "Tags",
+			want: ErrIncomparable,
+		},
+		"no_slice": {
+			data: person{},
+			spec: "Name",
+			want: ErrNotStructData,
+		},
+		"no_structs": {
+			data: []int{},
+			spec: "Name",
+			want: ErrNotStructData,
+		},
+	}
+	for name, test := range tests {
+		_, err := OrderBy(test.data, test.spec)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s: got error %v but want %v", name, err, test.want)
+		}
+	}
+}
+
+// TestOrderByCache tests that resolved fields are cached per type and spec.
+func TestOrderByCache(t *testing.T) {
+	if _, err := OrderBy([]person{}, "Age desc"); err != nil {
+		t.Fatal(err)
+	}
+	cacheKey := orderKeyCacheKey{reflect.TypeOf(person{}), "Age desc"}
+	if _, ok := orderKeyCache.Load(cacheKey); !ok {
+		t.Errorf("got no cache entry for %v", cacheKey)
+	}
+}
+
+// names returns the names of the specified persons.
+func names(persons []person) []string {
+	result := make([]string, len(persons))
+	for i, p := range persons {
+		result[i] = p.Name
+	}
+	return result
+}
@@ -0,0 +1,155 @@
+package gsorter
+
+import (
+	"cmp"
+	"sort"
+)
+
+// PartialStrategy selects the algorithm that PartialSorter uses.
+type PartialStrategy int
+
+const (
+	AutoStrategy        PartialStrategy = iota // chooses by the ratio of k to the length
+	HeapStrategy                               // O(n log k), best for small k
+	QuickselectStrategy                        // O(n + k log k), best for large k
+)
+
+// heapRatio is the minimum ratio of the length to k for which AutoStrategy
+// chooses HeapStrategy. Below it, too many elements enter the heap and
+// quickselect is faster, as BenchmarkPartialSort shows with random data.
+const heapRatio = 100
+
+// PartialSorter sorts only the smallest elements of some data. The zero
+// value chooses the strategy automatically.
+type PartialSorter struct {
+	Strategy PartialStrategy
+}
+
+// PartialSort moves the k smallest elements of the specified data to its
+// start in ascending order. The order of the remaining elements is
+// unspecified. A k that is not in the range from 0 to the length of the data
+// is clamped to it.
+func PartialSort(data sort.Interface, k int) {
+	PartialSorter{}.PartialSort(data, k)
+}
+
+// PartialSort moves the k smallest elements of the specified data to its
+// start in ascending order, see the package function PartialSort.
+func (s PartialSorter) PartialSort(data sort.Interface, k int) {
+	length := data.Len()
+	k = min(max(k, 0), length)
+	if s.strategy(length, k) == HeapStrategy {
+		selectWithHeap(data, k)
+	} else {
+		selectWithQuickselect(data, k)
+		heapify(data, k)
+	}
+	sortHeap(data, k)
+}
+
+// TopK returns a new slice with the k largest elements of the specified data
+// in descending order. The specified data is not modified. A k that is not in
+// the range from 0 to the length of the data is clamped to it.
+func TopK[T cmp.Ordered](data []T, k int) []T {
+	return TopKWith(data, k, cmp.Compare[T], AutoStrategy)
+}
+
+// TopKFunc returns a new slice with the k largest elements of the specified
+// data in descending order of the specified comparator, see TopK.
+func TopKFunc[T any](data []T, k int, comparator Comparator[T]) []T {
+	return TopKWith(data, k, comparator, AutoStrategy)
+}
+
+// TopKWith returns a new slice with the k largest elements of the specified
+// data in descending order of the specified comparator, selected with the
+// specified strategy, see TopK.
+func TopKWith[T any](data []T, k int, comparator Comparator[T], strategy PartialStrategy) []T {
+	k = min(max(k, 0), len(data))
+	descending := comparator.Reverse()
+	if (PartialSorter{strategy}).strategy(len(data), k) == QuickselectStrategy {
+		copied := append([]T{}, data...)
+		PartialSorter{QuickselectStrategy}.PartialSort(comparatorSortable[T]{copied, descending}, k)
+		return append(make([]T, 0, k), copied[:k]...)
+	}
+
+	// Keeps the k largest elements seen so far in a heap with the smallest
+	// one at the root, so that the data does not need to be copied.
+	result := append(make([]T, 0, k), data[:k]...)
+	heap := comparatorSortable[T]{result, descending}
+	heapify(heap, k)
+	for _, element := range data[k:] {
+		if k > 0 && comparator.Compare(element, result[0]) > 0 {
+			result[0] = element
+			siftDown(heap, 0, k)
+		}
+	}
+	sortHeap(heap, k)
+	return result
+}
+
+// strategy returns the strategy to use for selecting k of n elements.
+func (s PartialSorter) strategy(n int, k int) PartialStrategy {
+	if s.Strategy != AutoStrategy {
+		return s.Strategy
+	}
+	if k <= n/heapRatio {
+		return HeapStrategy
+	}
+	return QuickselectStrategy
+}
+
+// selectWithHeap moves the k smallest elements of the specified data to its
+// start, arranged as a max-heap.
+func selectWithHeap(data sort.Interface, k int) {
+	heapify(data, k)
+	for i := k; i < data.Len(); i++ {
+		if k > 0 && data.Less(i, 0) {
+			data.Swap(i, 0)
+			siftDown(data, 0, k)
+		}
+	}
+}
+
+// selectWithQuickselect moves the k smallest elements of the specified data
+// to its start, in unspecified order.
+func selectWithQuickselect(data sort.Interface, k int) {
+	if 0 < k && k < data.Len() {
+		Select(data, k-1)
+	}
+}
+
+// heapify arranges the specified number of elements at the start of the
+// data as a max-heap.
+func heapify(data sort.Interface, size int) {
+	for i := size/2 - 1; i >= 0; i-- {
+		siftDown(data, i, size)
+	}
+}
+
+// siftDown moves the element at the specified index of the heap down until
+// none of its children is greater.
+func siftDown(data sort.Interface, i int, size int) {
+	for {
+		child := 2*i + 1
+		if child >= size {
+			return
+		}
+		if child+1 < size && data.Less(child, child+1) {
+			child++
+		}
+		if !data.Less(i, child) {
+			return
+		}
+		data.Swap(i, child)
+		i = child
+	}
+}
+
+// sortHeap sorts the max-heap of the specified size at the start of the data
+// in ascending order.
+func sortHeap(data sort.Interface, size int) {
+	for end := size - 1; end > 0; end-- {
+		data.Swap(0, end)
+		siftDown(data, 0, end)
+	}
+}
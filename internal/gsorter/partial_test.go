@@ -0,0 +1,167 @@
+package gsorter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// partialStrategies are all strategies of PartialSorter.
+var partialStrategies = map[string]PartialStrategy{
+	"auto":        AutoStrategy,
+	"heap":        HeapStrategy,
+	"quickselect": QuickselectStrategy,
+}
+
+// TestPartialSort tests all strategies with small slices and edge cases of k.
+func TestPartialSort(t *testing.T) {
+	tests := map[string]struct {
+		slice    []int
+		k        int
+		want     []int
+		wantTopK []int
+	}{
+		"empty_input": {
+			slice:    []int{},
+			k:        3,
+			want:     []int{},
+			wantTopK: []int{},
+		},
+		"k_zero": {
+			slice:    []int{3, 1, 2},
+			k:        0,
+			want:     []int{},
+			wantTopK: []int{},
+		},
+		"k_negative": {
+			slice:    []int{3, 1, 2},
+			k:        -1,
+			want:     []int{},
+			wantTopK: []int{},
+		},
+		"k_one": {
+			slice:    []int{4, 7, -4, 2, -8, 9, 6},
+			k:        1,
+			want:     []int{-8},
+			wantTopK: []int{9},
+		},
+		"k_some": {
+			slice:    []int{4, 7, -4, 2, -8, 9, 6},
+			k:        3,
+			want:     []int{-8, -4, 2},
+			wantTopK: []int{9, 7, 6},
+		},
+		"k_length": {
+			slice:    []int{4, 7, 4, 2},
+			k:        4,
+			want:     []int{2, 4, 4, 7},
+			wantTopK: []int{7, 4, 4, 2},
+		},
+		"k_too_large": {
+			slice:    []int{3, 1, 2},
+			k:        10,
+			want:     []int{1, 2, 3},
+			wantTopK: []int{3, 2, 1},
+		},
+		"duplicates": {
+			slice:    []int{5, 5, 1, 5, 1, 5, 5},
+			k:        3,
+			want:     []int{1, 1, 5},
+			wantTopK: []int{5, 5, 5},
+		},
+	}
+	for name, test := range tests {
+		for strategyName, strategy := range partialStrategies {
+			slice := append([]int{}, test.slice...)
+			topK := TopKWith(slice, test.k, func(a, b int) int { return a - b }, strategy)
+			if !reflect.DeepEqual(topK, test.wantTopK) {
+				t.Errorf("%s/%s: TopK got %v but want %v", name, strategyName, topK, test.wantTopK)
+			}
+			if !reflect.DeepEqual(slice, test.slice) {
+				t.Errorf("%s/%s: TopK modified the input to %v", name, strategyName, slice)
+			}
+			PartialSorter{strategy}.PartialSort(IntSortable(slice), test.k)
+			if got := slice[:len(test.want)]; !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%s/%s: got %v but want %v", name, strategyName, got, test.want)
+			}
+		}
+	}
+}
+
+// TestLargePartialSort tests all strategies with large slices of all
+// distributions and with many equal elements.
+func TestLargePartialSort(t *testing.T) {
+	arrangements := append(append([]Distribution{}, Distributions...),
+		Distribution{"few_values", func(data sort.Interface) {
+			for i, element := range data.(IntSortable) {
+				data.(IntSortable)[i] = element % 3
+			}
+		}})
+	for _, distribution := range arrangements {
+		for _, k := range []int{1, 10, 500, 9999} {
+			original := CreateRandomInts(10000)
+			distribution.Arrange(IntSortable(original))
+			want := append([]int{}, original...)
+			sort.Ints(want)
+			for strategyName, strategy := range partialStrategies {
+				name := fmt.Sprintf("%s/k=%d/%s", distribution.Name, k, strategyName)
+				slice := append([]int{}, original...)
+				topK := TopKWith(slice, k, func(a, b int) int { return a - b }, strategy)
+				for i, element := range topK {
+					if element != want[len(want)-1-i] {
+						t.Errorf("%s: TopK got %v at %d but want %v", name, element, i, want[len(want)-1-i])
+						break
+					}
+				}
+				PartialSorter{strategy}.PartialSort(IntSortable(slice), k)
+				if !reflect.DeepEqual(slice[:k], want[:k]) {
+					t.Errorf("%s: got wrong prefix", name)
+				}
+				if !IsPermutation(slice, original) {
+					t.Errorf("%s: got no permutation of the input", name)
+				}
+			}
+		}
+	}
+}
+
+// TestTopK tests the generic TopK functions with strings and a comparator.
+func TestTopK(t *testing.T) {
+	words := strings.Fields("the quick brown fox jumps over the lazy dog")
+	if got, want := TopK(words, 3), []string{"the", "the", "quick"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q but want %q", got, want)
+	}
+	byLength := By(func(s string) int { return len(s) }).Then(By(func(s string) string { return s }))
+	if got, want := TopKFunc(words, 2, byLength), []string{"quick", "jumps"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q but want %q", got, want)
+	}
+	if got, want := TopKFunc(words, 2, byLength.Reverse()), []string{"dog", "fox"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reversed: got %q but want %q", got, want)
+	}
+}
+
+// BenchmarkPartialSort compares the strategies with a full sort for
+// different ratios of k to the length.
+func BenchmarkPartialSort(b *testing.B) {
+	const size = 100000
+	original := CreateRandomInts(size)
+	slice := make([]int, size)
+	for _, k := range []int{10, 100, 1000, 10000, 50000} {
+		for strategyName, strategy := range partialStrategies {
+			b.Run(fmt.Sprintf("k=%d/strategy=%s", k, strategyName), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					copy(slice, original)
+					PartialSorter{strategy}.PartialSort(IntSortable(slice), k)
+				}
+			})
+		}
+		b.Run(fmt.Sprintf("k=%d/strategy=full_sort", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(slice, original)
+				QuickSort(IntSortable(slice))
+			}
+		})
+	}
+}
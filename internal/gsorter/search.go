@@ -0,0 +1,183 @@
+package gsorter
+
+import "cmp"
+
+// The search functions expect the data to be sorted in ascending order, or in
+// the order of the comparator for the Func variants. They return indexes at
+// which the target could be inserted without breaking the order. The At
+// variants search n elements through an accessor, like sort.Search: compare
+// returns a negative number, zero or a positive number if the element at the
+// specified index is less than, equal to or greater than the target.
+
+// Number is a constraint for the types that InterpolationSearch can compute
+// positions with.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// LowerBound returns the index of the first element of the specified data
+// that is greater than or equal to the target, or the length of the data if
+// there is none. It uses binary search.
+func LowerBound[T cmp.Ordered](data []T, target T) int {
+	return LowerBoundFunc(data, target, cmp.Compare[T])
+}
+
+// LowerBoundFunc returns the index of the first element of the specified data
+// that is not less than the target in the order of the specified comparator.
+func LowerBoundFunc[T any](data []T, target T, comparator Comparator[T]) int {
+	return LowerBoundAt(len(data), func(i int) int { return comparator.Compare(data[i], target) })
+}
+
+// LowerBoundAt returns the least index in [0, n) at which compare is not
+// negative, or n if there is none.
+func LowerBoundAt(n int, compare func(i int) int) int {
+	low, high := 0, n
+	for low < high {
+		middle := int(uint(low+high) >> 1)
+		if compare(middle) < 0 {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	return low
+}
+
+// UpperBound returns the index of the first element of the specified data
+// that is greater than the target, or the length of the data if there is
+// none. It uses binary search.
+func UpperBound[T cmp.Ordered](data []T, target T) int {
+	return UpperBoundFunc(data, target, cmp.Compare[T])
+}
+
+// UpperBoundFunc returns the index of the first element of the specified data
+// that is greater than the target in the order of the specified comparator.
+func UpperBoundFunc[T any](data []T, target T, comparator Comparator[T]) int {
+	return UpperBoundAt(len(data), func(i int) int { return comparator.Compare(data[i], target) })
+}
+
+// UpperBoundAt returns the least index in [0, n) at which compare is
+// positive, or n if there is none.
+func UpperBoundAt(n int, compare func(i int) int) int {
+	low, high := 0, n
+	for low < high {
+		middle := int(uint(low+high) >> 1)
+		if compare(middle) <= 0 {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	return low
+}
+
+// EqualRange returns the range [from, to) of the elements of the specified
+// data that are equal to the target. If there are none, the range is empty
+// and from is the LowerBound.
+func EqualRange[T cmp.Ordered](data []T, target T) (from int, to int) {
+	return EqualRangeFunc(data, target, cmp.Compare[T])
+}
+
+// EqualRangeFunc returns the range [from, to) of the elements of the
+// specified data that are equal to the target in the order of the specified
+// comparator.
+func EqualRangeFunc[T any](data []T, target T, comparator Comparator[T]) (from int, to int) {
+	return EqualRangeAt(len(data), func(i int) int { return comparator.Compare(data[i], target) })
+}
+
+// EqualRangeAt returns the range [from, to) of the indexes in [0, n) at which
+// compare is zero.
+func EqualRangeAt(n int, compare func(i int) int) (from int, to int) {
+	from = LowerBoundAt(n, compare)
+	return from, from + UpperBoundAt(n-from, func(i int) int { return compare(from + i) })
+}
+
+// ExponentialSearch returns the LowerBound of the target and whether the
+// target was found. It probes the data from the start in steps that double in
+// size and then searches binary between the last two probes. This takes
+// O(log i) time for the resulting index i, so it is faster than binary search
+// for targets near the start of long data.
+func ExponentialSearch[T cmp.Ordered](data []T, target T) (int, bool) {
+	return ExponentialSearchFunc(data, target, cmp.Compare[T])
+}
+
+// ExponentialSearchFunc returns the LowerBoundFunc of the target and whether
+// the target was found, see ExponentialSearch.
+func ExponentialSearchFunc[T any](data []T, target T, comparator Comparator[T]) (int, bool) {
+	return ExponentialSearchAt(len(data), func(i int) int { return comparator.Compare(data[i], target) })
+}
+
+// ExponentialSearchAt returns the LowerBoundAt and whether compare is zero
+// there, see ExponentialSearch.
+func ExponentialSearchAt(n int, compare func(i int) int) (int, bool) {
+	// The result is in the range (low, high].
+	low, high := -1, 0
+	for high < n && compare(high) < 0 {
+		low, high = high, 2*high+1
+	}
+	high = min(high, n)
+	for low+1 < high {
+		middle := int(uint(low+high) >> 1)
+		if compare(middle) < 0 {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+	return high, high < n && compare(high) == 0
+}
+
+// InterpolationSearch returns the LowerBound of the target and whether the
+// target was found. It estimates the position of the target from the values
+// at the ends of the searched range, assuming that the values are evenly
+// distributed. It takes O(log log n) time on average for uniformly
+// distributed values, but O(n) time in the worst case, for example if the
+// values grow exponentially. The data must not contain NaNs.
+func InterpolationSearch[T Number](data []T, target T) (int, bool) {
+	return InterpolationSearchAt(len(data), func(i int) T { return data[i] }, target)
+}
+
+// InterpolationSearchBy returns the LowerBound of the target and whether the
+// target was found, see InterpolationSearch. The data must be sorted by the
+// specified key, which also gives the positions of the elements for the
+// interpolation.
+func InterpolationSearchBy[T any, K Number](data []T, target T, key func(T) K) (int, bool) {
+	return InterpolationSearchAt(len(data), func(i int) K { return key(data[i]) }, key(target))
+}
+
+// InterpolationSearchAt returns the least index in [0, n) at which the key is
+// not less than the target and whether they are equal, see
+// InterpolationSearch. The keys must ascend with the index.
+func InterpolationSearchAt[K Number](n int, key func(i int) K, target K) (int, bool) {
+	// The result is in the range [low, high].
+	low, high := 0, n
+	for low < high {
+		first, last := key(low), key(high-1)
+		if target <= first {
+			break
+		}
+		if target > last {
+			low = high
+			break
+		}
+		// Now first < target <= last, so the result is in (low, high-1]. If
+		// the target equals the last key, interpolation would only move high
+		// by one, so the range is halved to skip runs of duplicates. The
+		// position is estimated with floats, which cannot overflow, while the
+		// keys are compared exactly.
+		probe := low + (high-low)/2
+		if target < last {
+			fraction := (float64(target) - float64(first)) / (float64(last) - float64(first))
+			probe = low + int(fraction*float64(high-1-low))
+			probe = min(max(probe, low+1), high-1)
+		}
+		if key(probe) < target {
+			low = probe + 1
+		} else {
+			high = probe
+		}
+	}
+	return low, low < n && key(low) == target
+}
@@ -0,0 +1,202 @@
+package gsorter
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestSearch tests all search functions with small sorted slices of floats,
+// including the infinities.
+func TestSearch(t *testing.T) {
+	inf := math.Inf(1)
+	tests := map[string]struct {
+		data     []float64
+		target   float64
+		from, to int
+	}{
+		"empty_input": {[]float64{}, 1, 0, 0},
+		"before_all":  {[]float64{2, 4, 6}, 1, 0, 0},
+		"after_all":   {[]float64{2, 4, 6}, 7, 3, 3},
+		"last":        {[]float64{2, 4, 6}, 6, 2, 3},
+		"between":     {[]float64{2, 4.5, 6}, 4.25, 1, 1},
+		"duplicates":  {[]float64{1, 3, 3, 3, 5}, 3, 1, 4},
+		"infinities":  {[]float64{-inf, -1, 0, 0, inf, inf}, 0, 2, 4},
+		"infinity":    {[]float64{-inf, -1, 0, inf, inf}, inf, 3, 5},
+	}
+	for name, test := range tests {
+		found := test.from < test.to
+		if got := LowerBound(test.data, test.target); got != test.from {
+			t.Errorf("%s: lower bound got %d but want %d", name, got, test.from)
+		}
+		if got := UpperBound(test.data, test.target); got != test.to {
+			t.Errorf("%s: upper bound got %d but want %d", name, got, test.to)
+		}
+		if from, to := EqualRange(test.data, test.target); from != test.from || to != test.to {
+			t.Errorf("%s: equal range got [%d, %d) but want [%d, %d)", name, from, to, test.from, test.to)
+		}
+		if got, ok := ExponentialSearch(test.data, test.target); got != test.from || ok != found {
+			t.Errorf("%s: exponential search got %d, %v but want %d, %v", name, got, ok, test.from, found)
+		}
+		if got, ok := InterpolationSearch(test.data, test.target); got != test.from || ok != found {
+			t.Errorf("%s: interpolation search got %d, %v but want %d, %v", name, got, ok, test.from, found)
+		}
+	}
+}
+
+// TestSearchFunc tests the Func and By variants with strings sorted case
+// insensitively, and the At variants with a sort.Interface.
+func TestSearchFunc(t *testing.T) {
+	data := []string{"apple", "Banana", "BANANA", "banana", "cherry"}
+	if from, to := EqualRangeFunc(data, "bAnAnA", compareFold); from != 1 || to != 4 {
+		t.Errorf("equal range got [%d, %d) but want [1, 4)", from, to)
+	}
+	if got := LowerBoundFunc(data, "b", compareFold); got != 1 {
+		t.Errorf("lower bound got %d but want 1", got)
+	}
+	if got := UpperBoundFunc(data, "BANANA", compareFold); got != 4 {
+		t.Errorf("upper bound got %d but want 4", got)
+	}
+	if got, ok := ExponentialSearchFunc(data, "CHERRY", compareFold); got != 4 || !ok {
+		t.Errorf("exponential search got %d, %v but want 4, true", got, ok)
+	}
+	if from, to := EqualRangeFunc(data, "anything", nil); from != 0 || to != len(data) {
+		t.Errorf("equal range with a nil comparator got [%d, %d) but want [0, %d)", from, to, len(data))
+	}
+	if got := UpperBoundFunc(data, "anything", nil); got != len(data) {
+		t.Errorf("upper bound with a nil comparator got %d but want %d", got, len(data))
+	}
+	if got, ok := ExponentialSearchFunc(data, "anything", nil); got != 0 || !ok {
+		t.Errorf("exponential search with a nil comparator got %d, %v but want 0, true", got, ok)
+	}
+
+	words := []string{"a", "bb", "bb", "dddd", "eeeee"}
+	length := func(s string) float64 { return float64(len(s)) }
+	if got, ok := InterpolationSearchBy(words, "xx", length); got != 1 || !ok {
+		t.Errorf("interpolation search got %d, %v but want 1, true", got, ok)
+	}
+	if got, ok := InterpolationSearchBy(words, "xxx", length); got != 3 || ok {
+		t.Errorf("interpolation search got %d, %v but want 3, false", got, ok)
+	}
+
+	sortable := IntSortable{1, 3, 3, 7}
+	compare := func(i int) int { return sortable[i] - 3 }
+	if from, to := EqualRangeAt(sortable.Len(), compare); from != 1 || to != 3 {
+		t.Errorf("equal range at got [%d, %d) but want [1, 3)", from, to)
+	}
+}
+
+// TestLargeSearch tests all search functions with large slices of all
+// searchArrangements against sort.SearchInts.
+func TestLargeSearch(t *testing.T) {
+	for _, distribution := range searchArrangements {
+		name := distribution.Name
+		data := CreateRandomInts(10000)
+		distribution.Arrange(IntSortable(data))
+		data[len(data)/2] = data[len(data)/2+1] // at least one duplicate
+		slices.Sort(data)
+		targets := []int{math.MinInt, math.MaxInt, data[0], data[len(data)-1]}
+		for i := 0; i < 1000; i++ {
+			targets = append(targets, data[rand.Intn(len(data))], data[rand.Intn(len(data))]+1)
+		}
+		for _, target := range targets {
+			from := sort.SearchInts(data, target)
+			to := sort.SearchInts(data, target+1)
+			if target == math.MaxInt {
+				to = len(data)
+			}
+			found := from < to
+			if gotFrom, gotTo := EqualRange(data, target); gotFrom != from || gotTo != to {
+				t.Errorf("%s: equal range for %d got [%d, %d) but want [%d, %d)", name, target, gotFrom, gotTo, from, to)
+			}
+			if got, ok := ExponentialSearch(data, target); got != from || ok != found {
+				t.Errorf("%s: exponential search for %d got %d, %v but want %d, %v", name, target, got, ok, from, found)
+			}
+			if got, ok := InterpolationSearch(data, target); got != from || ok != found {
+				t.Errorf("%s: interpolation search for %d got %d, %v but want %d, %v", name, target, got, ok, from, found)
+			}
+		}
+	}
+}
+
+// searchArrangements are all distributions plus skewed values, on which
+// interpolation search degrades, and few different values, which give long
+// runs of equal values.
+var searchArrangements = append(append([]Distribution{}, Distributions...),
+	Distribution{"skewed", func(data sort.Interface) {
+		copy(data.(IntSortable), CreateSkewedInts(data.Len()))
+	}},
+	Distribution{"few_values", func(data sort.Interface) {
+		for i, element := range data.(IntSortable) {
+			data.(IntSortable)[i] = element % 10
+		}
+	}},
+)
+
+// FuzzSearch checks all search functions with fuzzed input against
+// sort.Search. The input bytes are taken as signed values.
+func FuzzSearch(f *testing.F) {
+	f.Add([]byte{1, 2, 3}, int8(2))
+	f.Add([]byte{0x80, 0, 0, 0x7f}, int8(0))
+	f.Fuzz(func(t *testing.T, input []byte, target int8) {
+		data := make([]int8, len(input))
+		for i, b := range input {
+			data[i] = int8(b)
+		}
+		slices.Sort(data)
+		from := sort.Search(len(data), func(i int) bool { return data[i] >= target })
+		to := sort.Search(len(data), func(i int) bool { return data[i] > target })
+		found := from < to
+		if gotFrom, gotTo := EqualRange(data, target); gotFrom != from || gotTo != to {
+			t.Fatalf("equal range for %d in %v: got [%d, %d) but want [%d, %d)", target, data, gotFrom, gotTo, from, to)
+		}
+		if got, ok := ExponentialSearch(data, target); got != from || ok != found {
+			t.Fatalf("exponential search for %d in %v: got %d, %v but want %d, %v", target, data, got, ok, from, found)
+		}
+		if got, ok := InterpolationSearch(data, target); got != from || ok != found {
+			t.Fatalf("interpolation search for %d in %v: got %d, %v but want %d, %v", target, data, got, ok, from, found)
+		}
+	})
+}
+
+// BenchmarkSearch benchmarks the generic search functions and slices.
+// BinarySearch with all searchArrangements, as ints and as strings that sort
+// like them. The targets are taken from the data, so that
+// they are distributed like it.
+func BenchmarkSearch(b *testing.B) {
+	for _, size := range []int{1000, 1000000} {
+		for _, distribution := range searchArrangements {
+			dataName := distribution.Name
+			data := CreateRandomInts(size)
+			distribution.Arrange(IntSortable(data))
+			slices.Sort(data)
+			strs := make([]string, len(data))
+			for i, x := range data {
+				strs[i] = fmt.Sprintf("%020d", x)
+			}
+			targets := make([]int, 1024)
+			for i := range targets {
+				targets[i] = rand.Intn(len(data))
+			}
+			searchFunctions := map[string]func(i int) int{
+				"LowerBound":          func(i int) int { return LowerBound(data, data[i]) },
+				"LowerBoundFunc":      func(i int) int { return LowerBoundFunc(strs, strs[i], strings.Compare) },
+				"BinarySearch":        func(i int) int { j, _ := slices.BinarySearch(data, data[i]); return j },
+				"ExponentialSearch":   func(i int) int { j, _ := ExponentialSearch(data, data[i]); return j },
+				"InterpolationSearch": func(i int) int { j, _ := InterpolationSearch(data, data[i]); return j },
+			}
+			for name, searchFunction := range searchFunctions {
+				benchmarkName := fmt.Sprintf("algorithm=%s/data=%s/n=%d", name, dataName, size)
+				b.Run(benchmarkName, func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						searchFunction(targets[i%len(targets)])
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package gsorter
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Select rearranges the specified data so that the element at index k is the
+// one that would be there if the data were sorted. All elements before it are
+// less than or equal to it and all elements after it are greater than or
+// equal to it. It uses the same partitioning as QuickSort and excludes the
+// elements equal to the pivot together with it in every round. If a round
+// does not shrink the range to three quarters, the next round uses a
+// median-of-medians pivot, which shrinks it to at most 70 percent. So it runs
+// in linear time even in the worst case, also with many equal elements.
+// Select panics if k is out of range.
+func Select(data sort.Interface, k int) {
+	if k < 0 || k >= data.Len() {
+		panic(fmt.Sprintf("gsorter: index %d out of range [0:%d]", k, data.Len()))
+	}
+	selectRange(data, 0, data.Len()-1, k)
+}
+
+// selectRange is an internal function for Select. It selects only within
+// those parts of the data specified by the 'from' and 'to' indexes.
+func selectRange(data sort.Interface, from int, to int, k int) {
+	useMedianOfMedians := false
+	for to-from >= 1 {
+		length := to - from + 1
+		if useMedianOfMedians {
+			data.Swap(from, medianOfMediansRange(data, from, to))
+		} else {
+			selectBestPivot(data, from, to)
+		}
+		pivotIndex := splitUsingPivot(data, from, to)
+
+		// Elements equal to the pivot all end up before it. They are moved
+		// next to it, so that they are excluded together with it and cannot
+		// keep the range from shrinking.
+		equalIndex := gatherEqual(data, from, pivotIndex)
+
+		switch {
+		case k < equalIndex:
+			to = equalIndex - 1
+		case k > pivotIndex:
+			from = pivotIndex + 1
+		default:
+			return
+		}
+		useMedianOfMedians = 4*(to-from+1) > 3*length
+	}
+}
+
+// gatherEqual moves the elements from the specified index up to the pivot
+// that are equal to the pivot right in front of it. It returns the index of
+// the first element that is equal to the pivot.
+func gatherEqual(data sort.Interface, from int, pivotIndex int) int {
+	equalIndex := pivotIndex
+	for i := pivotIndex - 1; i >= from; i-- {
+		if !data.Less(i, equalIndex) {
+			equalIndex--
+			data.Swap(i, equalIndex)
+		}
+	}
+	return equalIndex
+}
+
+// MedianOfMedians returns the index of an approximate median of the specified
+// data, which is greater than or equal to at least 30 percent of the elements
+// and less than or equal to at least 30 percent of them. It sorts groups of
+// five elements, moves their medians to the start of the data and selects the
+// median of those. The data must not be empty.
+func MedianOfMedians(data sort.Interface) int {
+	return medianOfMediansRange(data, 0, data.Len()-1)
+}
+
+// medianOfMediansRange is an internal function for MedianOfMedians. It
+// considers only those parts of the data specified by the 'from' and 'to'
+// indexes.
+func medianOfMediansRange(data sort.Interface, from int, to int) int {
+	if to-from < 4 {
+		insertionSortRange(data, from, to)
+		return from + (to-from)/2
+	}
+	groups := 0
+	for i := from; i+4 <= to; i += 5 {
+		insertionSortRange(data, i, i+4)
+		data.Swap(from+groups, i+2)
+		groups++
+	}
+	median := from + (groups-1)/2
+	selectRange(data, from, from+groups-1, median)
+	return median
+}
+
+// insertionSortRange sorts the short part of the data specified by the
+// 'from' and 'to' indexes using the insertion sort algorithm.
+func insertionSortRange(data sort.Interface, from int, to int) {
+	for i := from + 1; i <= to; i++ {
+		for j := i; j > from && data.Less(j, j-1); j-- {
+			data.Swap(j, j-1)
+		}
+	}
+}
+
+// Median returns the index of the lower median of the specified data after
+// rearranging it like Select. It panics if the data is empty.
+func Median(data sort.Interface) int {
+	k := (data.Len() - 1) / 2
+	Select(data, k)
+	return k
+}
+
+// Quantiles returns the indexes of the specified quantiles of the specified
+// data, each between 0 and 1, in the order of the arguments. The q-quantile
+// is the element at the nearest rank ceil(q*n) in the sorted data, or the
+// smallest element for q = 0. The data is rearranged like Select for every
+// quantile, so that afterwards every returned index holds its quantile.
+// Quantiles panics if the data is empty or a quantile is out of range.
+func Quantiles(data sort.Interface, qs ...float64) []int {
+	length := data.Len()
+	if length == 0 {
+		panic("gsorter: quantiles of empty data")
+	}
+	indexes := make([]int, len(qs))
+	for i, q := range qs {
+		if !(0 <= q && q <= 1) {
+			panic(fmt.Sprintf("gsorter: quantile %v out of range [0, 1]", q))
+		}
+		indexes[i] = max(int(math.Ceil(q*float64(length)))-1, 0)
+	}
+
+	// Selecting the indexes in ascending order leaves every range between
+	// two of them for the next selection.
+	ascending := append([]int{}, indexes...)
+	sort.Ints(ascending)
+	from := 0
+	for _, index := range ascending {
+		if index >= from {
+			selectRange(data, from, length-1, index)
+			from = index + 1
+		}
+	}
+	return indexes
+}
@@ -0,0 +1,178 @@
+package gsorter
+
+import (
+	"sort"
+	"strings"
+)
+
+// InvalidVersionPolicy specifies where strings that are not valid semantic
+// versions are placed.
+type InvalidVersionPolicy int
+
+const (
+	InvalidFirst InvalidVersionPolicy = iota // invalid versions are less than all valid ones
+	InvalidLast                              // invalid versions are greater than all valid ones
+)
+
+// SemVerOrder specifies the order of semantic versions, see
+// https://semver.org/spec/v2.0.0.html. Versions are ordered by precedence:
+// major, minor and patch version are compared numerically, a pre-release
+// version is less than the associated normal version and pre-release
+// identifiers are compared one by one. A leading "v" is allowed and build
+// metadata is ignored, so "v1.0.0" and "1.0.0+build.5" are equal. Invalid
+// versions are placed according to the policy and compared with
+// CompareNatural among each other. The zero value places invalid versions
+// first.
+type SemVerOrder struct {
+	Invalid InvalidVersionPolicy
+}
+
+// Strings returns a sort.Interface for the specified data that sorts it in
+// this order.
+func (o SemVerOrder) Strings(data []string) sort.Interface {
+	return comparatorSortable[string]{data, o.Compare}
+}
+
+// Compare compares the specified versions in this order. It returns a
+// negative number, zero or a positive number like strings.Compare.
+func (o SemVerOrder) Compare(a, b string) int {
+	versionA, okA := parseSemVer(a)
+	versionB, okB := parseSemVer(b)
+	switch {
+	case !okA && !okB:
+		return CompareNatural(a, b)
+	case !okA && o.Invalid == InvalidFirst, !okB && o.Invalid == InvalidLast:
+		return -1
+	case !okA, !okB:
+		return 1
+	}
+	return versionA.compare(versionB)
+}
+
+// SemVerSortable is a convenience wrapper for string slices with semantic
+// versions that are to be sorted. It uses the zero SemVerOrder, so invalid
+// versions come first.
+type SemVerSortable []string
+
+func (a SemVerSortable) Len() int           { return len(a) }
+func (a SemVerSortable) Less(i, j int) bool { return SemVerOrder{}.Compare(a[i], a[j]) < 0 }
+func (a SemVerSortable) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+// IsValidSemVer reports whether the specified string is a valid semantic
+// version, optionally with a leading "v".
+func IsValidSemVer(s string) bool {
+	_, ok := parseSemVer(s)
+	return ok
+}
+
+// semVer is a parsed semantic version without build metadata. The numbers
+// are kept as strings so that they can have any number of digits.
+type semVer struct {
+	major, minor, patch string
+	preRelease          []string
+}
+
+// parseSemVer parses the specified semantic version.
+func parseSemVer(s string) (semVer, bool) {
+	s = strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		if !validIdentifiers(s[i+1:], false) {
+			return semVer{}, false
+		}
+		s = s[:i]
+	}
+	var version semVer
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		if !validIdentifiers(s[i+1:], true) {
+			return semVer{}, false
+		}
+		version.preRelease = strings.Split(s[i+1:], ".")
+		s = s[:i]
+	}
+	numbers := strings.Split(s, ".")
+	if len(numbers) != 3 {
+		return semVer{}, false
+	}
+	for _, number := range numbers {
+		if !isNumeric(number) || len(number) > 1 && number[0] == '0' {
+			return semVer{}, false
+		}
+	}
+	version.major, version.minor, version.patch = numbers[0], numbers[1], numbers[2]
+	return version, true
+}
+
+// validIdentifiers reports whether the specified string consists of
+// non-empty, dot-separated identifiers of ASCII letters, digits and hyphens.
+// If preRelease is true, numeric identifiers must not have leading zeros.
+func validIdentifiers(s string, preRelease bool) bool {
+	for _, identifier := range strings.Split(s, ".") {
+		if identifier == "" {
+			return false
+		}
+		for i := 0; i < len(identifier); i++ {
+			c := identifier[i]
+			if !isDigit(c) && c != '-' && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') {
+				return false
+			}
+		}
+		if preRelease && isNumeric(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumeric reports whether the specified string is a non-empty run of the
+// digits 0 to 9.
+func isNumeric(s string) bool {
+	return s != "" && digitRun(s) == s
+}
+
+// compareNumeric compares the specified numbers without leading zeros.
+func compareNumeric(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+// compare compares the precedence of the specified versions.
+func (v semVer) compare(other semVer) int {
+	if result := compareNumeric(v.major, other.major); result != 0 {
+		return result
+	}
+	if result := compareNumeric(v.minor, other.minor); result != 0 {
+		return result
+	}
+	if result := compareNumeric(v.patch, other.patch); result != 0 {
+		return result
+	}
+	switch {
+	case len(v.preRelease) == 0 && len(other.preRelease) == 0:
+		return 0
+	case len(v.preRelease) == 0:
+		return 1
+	case len(other.preRelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.preRelease) && i < len(other.preRelease); i++ {
+		a, b := v.preRelease[i], other.preRelease[i]
+		numericA, numericB := isNumeric(a), isNumeric(b)
+		var result int
+		switch {
+		case numericA && numericB:
+			result = compareNumeric(a, b)
+		case numericA:
+			result = -1
+		case numericB:
+			result = 1
+		default:
+			result = strings.Compare(a, b)
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return len(v.preRelease) - len(other.preRelease)
+}
@@ -0,0 +1,131 @@
+package gsorter
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSemVerOrder tests all sort functions with semantic versions in the
+// orders of the invalid version policies.
+func TestSemVerOrder(t *testing.T) {
+	valid := []string{
+		"0.9.0",
+		"1.0.0-0.3.7",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"v1.0.0",
+		"1.0.1",
+		"v1.9.0",
+		"v1.10.0",
+		"1.10.1-x-y-z.--",
+		"2.0.0",
+		"10.0.0",
+		"99999999999999999999.0.0",
+		"100000000000000000000.0.0",
+	}
+	invalid := []string{"", "1.0", "1.0.0-", "1.0.0-01", "01.0.0", "latest", "v1.2.3.4", "vv1.0.0"}
+	wantInvalid := []string{"", "1.0", "01.0.0", "1.0.0-", "1.0.0-01", "latest", "v1.2.3.4", "vv1.0.0"}
+
+	tests := map[string]struct {
+		order SemVerOrder
+		want  []string
+	}{
+		"invalid_first": {
+			order: SemVerOrder{},
+			want:  append(append([]string{}, wantInvalid...), valid...),
+		},
+		"invalid_last": {
+			order: SemVerOrder{Invalid: InvalidLast},
+			want:  append(append([]string{}, valid...), wantInvalid...),
+		},
+	}
+	for name, test := range tests {
+		for _, sortFunction := range SortFunctions {
+			slice := shuffledStrings(append(append([]string{}, valid...), invalid...))
+			sortFunction(test.order.Strings(slice))
+			if !reflect.DeepEqual(slice, test.want) {
+				t.Errorf("%s: got %q but want %q", name, slice, test.want)
+			}
+		}
+	}
+	for _, sortFunction := range SortFunctions {
+		slice := shuffledStrings(valid)
+		sortFunction(SemVerSortable(slice))
+		if !reflect.DeepEqual(slice, valid) {
+			t.Errorf("sortable: got %q but want %q", slice, valid)
+		}
+	}
+}
+
+// TestSemVerEqual tests that build metadata and a leading "v" do not affect
+// the precedence.
+func TestSemVerEqual(t *testing.T) {
+	tests := map[string]struct {
+		a, b string
+	}{
+		"prefix":           {"v1.2.3", "1.2.3"},
+		"build":            {"1.2.3+build.5", "1.2.3"},
+		"build_zeros":      {"1.2.3+001", "1.2.3+exp.sha.5114f85"},
+		"prerelease_build": {"v1.2.3-rc.1+build", "1.2.3-rc.1"},
+	}
+	for name, test := range tests {
+		if got := (SemVerOrder{}).Compare(test.a, test.b); got != 0 {
+			t.Errorf("%s: got %v but want 0", name, got)
+		}
+	}
+}
+
+// TestIsValidSemVer tests the validation of semantic versions.
+func TestIsValidSemVer(t *testing.T) {
+	tests := map[string]struct {
+		version string
+		want    bool
+	}{
+		"normal":             {"1.2.3", true},
+		"prefix":             {"v1.2.3", true},
+		"prerelease":         {"1.2.3-alpha.1", true},
+		"prerelease_hyphen":  {"1.2.3---", true},
+		"build":              {"1.2.3+build.01", true},
+		"missing_patch":      {"1.2", false},
+		"leading_zero":       {"1.02.3", false},
+		"prerelease_zero":    {"1.2.3-alpha.01", false},
+		"empty_identifier":   {"1.2.3-alpha..1", false},
+		"empty_build":        {"1.2.3+", false},
+		"invalid_character":  {"1.2.3-alpha_1", false},
+		"negative":           {"-1.2.3", false},
+		"uppercase_prefix":   {"V1.2.3", false},
+		"whitespace":         {" 1.2.3", false},
+		"non_ascii_digit":    {"1.2.٣", false},
+		"build_before_minus": {"1.2.3+build-1", true},
+	}
+	for name, test := range tests {
+		if got := IsValidSemVer(test.version); got != test.want {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
+
+// FuzzSemVerOrder checks that the semantic version order is antisymmetric
+// and transitive for fuzzed strings.
+func FuzzSemVerOrder(f *testing.F) {
+	f.Add("1.0.0", "1.0.0-alpha", "v1.0.0+build")
+	f.Add("1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-1")
+	f.Add("invalid", "1.0", "2.0.0")
+	f.Add("1.0.0-rc.1", "1.0.0-rc.01", "1.0.0-rc.a")
+	f.Fuzz(func(t *testing.T, a, b, c string) {
+		for _, order := range []SemVerOrder{{Invalid: InvalidFirst}, {Invalid: InvalidLast}} {
+			ab, bc, ac := sign(order.Compare(a, b)), sign(order.Compare(b, c)), sign(order.Compare(a, c))
+			if ab != -sign(order.Compare(b, a)) {
+				t.Errorf("%q, %q: comparison is not antisymmetric", a, b)
+			}
+			if ab <= 0 && bc <= 0 && ac > 0 || ab >= 0 && bc >= 0 && ac < 0 {
+				t.Errorf("%q, %q, %q: comparison is not transitive", a, b, c)
+			}
+		}
+	})
+}
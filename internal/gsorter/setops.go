@@ -0,0 +1,227 @@
+package gsorter
+
+import "cmp"
+
+// The set operations treat sorted slices as sets: the slices may contain
+// duplicates, but the results contain every element only once. The Func
+// variants expect the slices to be sorted in the order of the comparator and
+// consider elements equal if the comparator returns 0 for them.
+
+// gallopRatio is the minimum ratio of the lengths of two slices from which on
+// the elements of the shorter slice are searched in the longer one by
+// galloping, instead of walking through both slices.
+const gallopRatio = 16
+
+// Unique removes the duplicates from the specified sorted slice in place and
+// returns the shortened slice.
+func Unique[T cmp.Ordered](data []T) []T {
+	return UniqueFunc(data, cmp.Compare[T])
+}
+
+// UniqueFunc removes the duplicates from the specified sorted slice in place
+// and returns the shortened slice. Of every group of equal elements, the first
+// one is kept.
+func UniqueFunc[T any](data []T, comparator Comparator[T]) []T {
+	if len(data) == 0 {
+		return data
+	}
+	length := 1
+	for i := 1; i < len(data); i++ {
+		if comparator.Compare(data[i], data[length-1]) != 0 {
+			data[length] = data[i]
+			length++
+		}
+	}
+	return data[:length]
+}
+
+// Union returns a new sorted slice with the elements that are in a or b.
+func Union[T cmp.Ordered](a []T, b []T) []T {
+	return UnionFunc(a, b, cmp.Compare[T])
+}
+
+// UnionFunc returns a new sorted slice with the elements that are in a or b.
+// Of equal elements, the one from a is kept.
+func UnionFunc[T any](a []T, b []T, comparator Comparator[T]) []T {
+	result := make([]T, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		var next T
+		switch {
+		case j == len(b) || i < len(a) && comparator.Compare(a[i], b[j]) < 0:
+			next = a[i]
+			i++
+		case i == len(a) || comparator.Compare(b[j], a[i]) < 0:
+			next = b[j]
+			j++
+		default:
+			next = a[i]
+			i++
+			j++
+		}
+		result = appendUnique(result, next, comparator)
+	}
+	return result
+}
+
+// Intersect returns a new sorted slice with the elements that are in both a
+// and b.
+func Intersect[T cmp.Ordered](a []T, b []T) []T {
+	return IntersectFunc(a, b, cmp.Compare[T])
+}
+
+// IntersectFunc returns a new sorted slice with the elements that are in both
+// a and b. Of equal elements, the one from a is kept.
+func IntersectFunc[T any](a []T, b []T, comparator Comparator[T]) []T {
+	result := []T{}
+	if len(a) > len(b) {
+		// Drives through the shorter slice and searches the longer one.
+		search := searchFunction(b, a)
+		i := 0
+		for _, element := range b {
+			if i = search(a, i, element, comparator); i == len(a) {
+				break
+			}
+			if comparator.Compare(a[i], element) == 0 {
+				result = appendUnique(result, a[i], comparator)
+			}
+		}
+		return result
+	}
+	search := searchFunction(a, b)
+	j := 0
+	for _, element := range a {
+		if j = search(b, j, element, comparator); j == len(b) {
+			break
+		}
+		if comparator.Compare(b[j], element) == 0 {
+			result = appendUnique(result, element, comparator)
+		}
+	}
+	return result
+}
+
+// Difference returns a new sorted slice with the elements of a that are not
+// in b.
+func Difference[T cmp.Ordered](a []T, b []T) []T {
+	return DifferenceFunc(a, b, cmp.Compare[T])
+}
+
+// DifferenceFunc returns a new sorted slice with the elements of a that are
+// not in b.
+func DifferenceFunc[T any](a []T, b []T, comparator Comparator[T]) []T {
+	result := []T{}
+	search := searchFunction(a, b)
+	j := 0
+	for _, element := range a {
+		j = search(b, j, element, comparator)
+		if j == len(b) || comparator.Compare(b[j], element) != 0 {
+			result = appendUnique(result, element, comparator)
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns a new sorted slice with the elements that are
+// in either a or b, but not in both.
+func SymmetricDifference[T cmp.Ordered](a []T, b []T) []T {
+	return SymmetricDifferenceFunc(a, b, cmp.Compare[T])
+}
+
+// SymmetricDifferenceFunc returns a new sorted slice with the elements that
+// are in either a or b, but not in both.
+func SymmetricDifferenceFunc[T any](a []T, b []T, comparator Comparator[T]) []T {
+	result := []T{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || i < len(a) && comparator.Compare(a[i], b[j]) < 0:
+			result = appendUnique(result, a[i], comparator)
+			i++
+		case i == len(a) || comparator.Compare(b[j], a[i]) < 0:
+			result = appendUnique(result, b[j], comparator)
+			j++
+		default:
+			// Skips all duplicates of the common element.
+			common := a[i]
+			for i < len(a) && comparator.Compare(a[i], common) == 0 {
+				i++
+			}
+			for j < len(b) && comparator.Compare(b[j], common) == 0 {
+				j++
+			}
+		}
+	}
+	return result
+}
+
+// IsSubset reports whether every element of a is also in b.
+func IsSubset[T cmp.Ordered](a []T, b []T) bool {
+	return IsSubsetFunc(a, b, cmp.Compare[T])
+}
+
+// IsSubsetFunc reports whether every element of a is also in b.
+func IsSubsetFunc[T any](a []T, b []T, comparator Comparator[T]) bool {
+	search := searchFunction(a, b)
+	j := 0
+	for _, element := range a {
+		j = search(b, j, element, comparator)
+		if j == len(b) || comparator.Compare(b[j], element) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// appendUnique appends the specified element to the sorted slice unless it
+// is equal to the last element.
+func appendUnique[T any](data []T, element T, comparator Comparator[T]) []T {
+	if len(data) > 0 && comparator.Compare(data[len(data)-1], element) == 0 {
+		return data
+	}
+	return append(data, element)
+}
+
+// searchFunction returns the function that finds the elements of a in b. If b
+// is much longer than a, galloping is faster than walking through b.
+func searchFunction[T any](a []T, b []T) func(data []T, from int, element T, comparator Comparator[T]) int {
+	if len(b) >= gallopRatio*len(a) {
+		return gallop[T]
+	}
+	return walk[T]
+}
+
+// walk returns the index of the first element from the specified index on
+// that is greater than or equal to the specified element, or the length of
+// the slice if there is none. It walks through the slice one by one.
+func walk[T any](data []T, from int, element T, comparator Comparator[T]) int {
+	for from < len(data) && comparator.Compare(data[from], element) < 0 {
+		from++
+	}
+	return from
+}
+
+// gallop returns the same index as walk, but it probes the slice in steps
+// that double in size and then searches binary between the last two probes.
+// This takes O(log d) time for the distance d to the result.
+func gallop[T any](data []T, from int, element T, comparator Comparator[T]) int {
+	low, step := from, 1
+	for low+step < len(data) && comparator.Compare(data[low+step], element) < 0 {
+		low += step
+		step *= 2
+	}
+	if low < len(data) && comparator.Compare(data[low], element) >= 0 {
+		return low
+	}
+	// The result is in the range (low, high].
+	high := min(low+step, len(data))
+	for low+1 < high {
+		middle := low + (high-low)/2
+		if comparator.Compare(data[middle], element) < 0 {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+	return high
+}
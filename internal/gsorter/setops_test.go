@@ -0,0 +1,155 @@
+package gsorter
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestSetOperations tests all set operations with small sorted slices of
+// strings.
+func TestSetOperations(t *testing.T) {
+	tests := map[string]struct {
+		a, b                  []string
+		union, intersect      []string
+		difference, symmetric []string
+		subset                bool
+	}{
+		"both_empty": {
+			a: []string{}, b: []string{},
+			union: []string{}, intersect: []string{},
+			difference: []string{}, symmetric: []string{},
+			subset: true,
+		},
+		"a_empty": {
+			a: []string{}, b: []string{"a", "b"},
+			union: []string{"a", "b"}, intersect: []string{},
+			difference: []string{}, symmetric: []string{"a", "b"},
+			subset: true,
+		},
+		"overlapping": {
+			a: []string{"a", "c", "d", "f"}, b: []string{"b", "c", "f", "g"},
+			union: []string{"a", "b", "c", "d", "f", "g"}, intersect: []string{"c", "f"},
+			difference: []string{"a", "d"}, symmetric: []string{"a", "b", "d", "g"},
+			subset: false,
+		},
+		"duplicates": {
+			a: []string{"a", "a", "b", "b"}, b: []string{"a", "b", "b", "b", "c"},
+			union: []string{"a", "b", "c"}, intersect: []string{"a", "b"},
+			difference: []string{}, symmetric: []string{"c"},
+			subset: true,
+		},
+	}
+	for name, test := range tests {
+		if got := Union(test.a, test.b); !reflect.DeepEqual(got, test.union) {
+			t.Errorf("%s: union got %v but want %v", name, got, test.union)
+		}
+		if got := Intersect(test.a, test.b); !reflect.DeepEqual(got, test.intersect) {
+			t.Errorf("%s: intersect got %v but want %v", name, got, test.intersect)
+		}
+		if got := Difference(test.a, test.b); !reflect.DeepEqual(got, test.difference) {
+			t.Errorf("%s: difference got %v but want %v", name, got, test.difference)
+		}
+		if got := SymmetricDifference(test.a, test.b); !reflect.DeepEqual(got, test.symmetric) {
+			t.Errorf("%s: symmetric difference got %v but want %v", name, got, test.symmetric)
+		}
+		if got := IsSubset(test.a, test.b); got != test.subset {
+			t.Errorf("%s: subset got %v but want %v", name, got, test.subset)
+		}
+	}
+}
+
+// TestSetOperationsFunc tests that the Func variants use the comparator for
+// equality and keep the elements of the first slice.
+func TestSetOperationsFunc(t *testing.T) {
+	a := []string{"Apple", "apple", "Banana", "cherry"}
+	b := []string{"APPLE", "cherry", "CHERRY", "date"}
+	long := []string{"aardvark"}
+	for len(long) < 100 {
+		long = append(long, "b"+strings.Repeat("x", len(long)))
+	}
+	long = append(long, "BANANA", "zebra")
+	slices.SortFunc(long, compareFold)
+
+	tests := map[string]struct {
+		got  []string
+		want []string
+	}{
+		"unique":                  {UniqueFunc(slices.Clone(a), compareFold), []string{"Apple", "Banana", "cherry"}},
+		"union":                   {UnionFunc(a, b, compareFold), []string{"Apple", "Banana", "cherry", "date"}},
+		"intersect":               {IntersectFunc(a, b, compareFold), []string{"Apple", "cherry"}},
+		"intersect_longer_first":  {IntersectFunc(long, a, compareFold), []string{"BANANA"}},
+		"intersect_longer_second": {IntersectFunc(a, long, compareFold), []string{"Banana"}},
+		"difference":              {DifferenceFunc(a, b, compareFold), []string{"Banana"}},
+		"difference_gallop":       {DifferenceFunc(a, long, compareFold), []string{"Apple", "cherry"}},
+		"symmetric":               {SymmetricDifferenceFunc(a, b, compareFold), []string{"Banana", "date"}},
+		"unique_nil":              {UniqueFunc(slices.Clone(a), nil), []string{"Apple"}},
+		"union_nil":               {UnionFunc(a, b, nil), []string{"Apple"}},
+		"intersect_nil":           {IntersectFunc(a, b, nil), []string{"Apple"}},
+		"difference_nil":          {DifferenceFunc(a, b, nil), []string{}},
+		"symmetric_nil":           {SymmetricDifferenceFunc(a, b, nil), []string{}},
+	}
+	for name, test := range tests {
+		if !reflect.DeepEqual(test.got, test.want) {
+			t.Errorf("%s: got %v but want %v", name, test.got, test.want)
+		}
+	}
+	if !IsSubsetFunc([]string{"APPLE", "CHERRY"}, a, compareFold) || IsSubsetFunc(b, a, compareFold) {
+		t.Errorf("got wrong subset results")
+	}
+	if !IsSubsetFunc(b, a, nil) || IsSubsetFunc(b, nil, nil) {
+		t.Errorf("got wrong subset results with a nil comparator")
+	}
+}
+
+// FuzzSetOperations checks the set operations on fuzzed sorted slices
+// against map-based reference implementations. Every input byte is taken as
+// a signed value, and the second slice is repeated to make it much longer
+// than the first one in some cases, so that galloping is used.
+func FuzzSetOperations(f *testing.F) {
+	f.Add([]byte{1, 2, 3}, []byte{2, 3, 4}, uint8(1))
+	f.Add([]byte{}, []byte{5, 5, 5}, uint8(0))
+	f.Add([]byte{0x80, 0, 0x7f}, []byte{0x7f, 0x80}, uint8(40))
+	f.Fuzz(func(t *testing.T, aBytes []byte, bBytes []byte, repeat uint8) {
+		a := make([]int8, len(aBytes))
+		for i, b := range aBytes {
+			a[i] = int8(b)
+		}
+		var b []int8
+		for r := 0; r <= int(repeat); r++ {
+			for _, element := range bBytes {
+				b = append(b, int8(element))
+			}
+		}
+		slices.Sort(a)
+		slices.Sort(b)
+
+		check := func(name string, got []int8, include func(inA, inB bool) bool) {
+			inA, inB := make(map[int8]bool), make(map[int8]bool)
+			for _, element := range a {
+				inA[element] = true
+			}
+			for _, element := range b {
+				inB[element] = true
+			}
+			want := []int8{}
+			for element := -128; element < 128; element++ {
+				if include(inA[int8(element)], inB[int8(element)]) {
+					want = append(want, int8(element))
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s of %v and %v: got %v but want %v", name, a, b, got, want)
+			}
+		}
+		check("union", Union(a, b), func(inA, inB bool) bool { return inA || inB })
+		check("intersect", Intersect(a, b), func(inA, inB bool) bool { return inA && inB })
+		check("difference", Difference(a, b), func(inA, inB bool) bool { return inA && !inB })
+		check("symmetric difference", SymmetricDifference(a, b), func(inA, inB bool) bool { return inA != inB })
+		if got, want := IsSubset(a, b), len(Difference(a, b)) == 0; got != want {
+			t.Fatalf("subset of %v and %v: got %v but want %v", a, b, got, want)
+		}
+		check("unique", Unique(slices.Clone(a)), func(inA, inB bool) bool { return inA })
+	})
+}
@@ -0,0 +1,88 @@
+package gsorter
+
+import (
+	"sort"
+	"time"
+)
+
+// secondsPerDay is the number of seconds of a day without leap seconds.
+const secondsPerDay = 24 * 60 * 60
+
+// TimeOrder specifies an order of time.Time values. The zero value orders by
+// instant, like TimeSortable.
+type TimeOrder struct {
+	// WallClock orders by the clock reading instead of the instant, so that
+	// 10:00 in Berlin comes after 9:00 in New York.
+	WallClock bool
+
+	// DateOnly orders by the calendar date of the clock reading and treats
+	// all times of the same day as equal. It implies WallClock.
+	DateOnly bool
+
+	// Location is where the clock readings are taken. If it is nil, every
+	// time uses its own location.
+	Location *time.Location
+}
+
+// Times returns a sort.Interface for the specified data that sorts it in
+// this order.
+func (o TimeOrder) Times(data []time.Time) sort.Interface {
+	return timeOrderSortable{data, o}
+}
+
+// timeOrderSortable sorts time.Time values in a configurable order.
+type timeOrderSortable struct {
+	data  []time.Time
+	order TimeOrder
+}
+
+func (a timeOrderSortable) Len() int { return len(a.data) }
+func (a timeOrderSortable) Less(i, j int) bool {
+	if !a.order.WallClock && !a.order.DateOnly {
+		return a.data[i].Before(a.data[j])
+	}
+	secondsI, nanosI := timeKey(a.data[i], a.order)
+	secondsJ, nanosJ := timeKey(a.data[j], a.order)
+	return secondsI < secondsJ || secondsI == secondsJ && nanosI < nanosJ
+}
+func (a timeOrderSortable) Swap(i, j int) { a.data[i], a.data[j] = a.data[j], a.data[i] }
+
+// timeKey returns the seconds since the epoch and the nanoseconds within the
+// second by which the specified time is ordered. For wall clock orders, the
+// seconds are those of the clock reading taken as UTC.
+func timeKey(t time.Time, order TimeOrder) (int64, int) {
+	if !order.WallClock && !order.DateOnly {
+		return t.Unix(), t.Nanosecond()
+	}
+	if order.Location != nil {
+		t = t.In(order.Location)
+	}
+	_, offset := t.Zone()
+	seconds := t.Unix() + int64(offset)
+	if order.DateOnly {
+		days := seconds / secondsPerDay
+		if seconds%secondsPerDay < 0 {
+			days-- // round towards negative infinity
+		}
+		return days * secondsPerDay, 0
+	}
+	return seconds, t.Nanosecond()
+}
+
+// RadixSortTimes sorts the specified data in the specified order using a
+// least significant digit radix sort, first on the nanoseconds and then on
+// the seconds of the times. It runs in linear time and is stable. Unlike
+// TimeSortable, it ignores monotonic clock readings.
+func RadixSortTimes(data []time.Time, order TimeOrder) {
+	keys := make([]uint64, len(data))
+	for i, t := range data {
+		_, nanos := timeKey(t, order)
+		keys[i] = uint64(nanos)
+	}
+	radixSortKeys(keys, data, 32)
+	for i, t := range data {
+		seconds, _ := timeKey(t, order)
+		keys[i] = uint64(seconds) ^ 1<<63 // negative seconds come first
+	}
+	radixSortKeys(keys, data, 64)
+}
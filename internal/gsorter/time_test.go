@@ -0,0 +1,153 @@
+package gsorter
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestExtremeTimes tests all sort functions and the radix sort with times
+// outside of the range of UnixNano, which ends in the years 1677 and 2262.
+func TestExtremeTimes(t *testing.T) {
+	want := []time.Time{
+		time.Date(-500, time.March, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1000, time.January, 1, 0, 0, 0, 5, time.UTC),
+		time.Date(1600, time.June, 30, 12, 0, 0, 0, time.UTC),
+		time.Date(1969, time.December, 31, 23, 59, 59, 999999999, time.UTC),
+		time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, time.March, 2, 10, 10, 0, 0, time.UTC),
+		time.Date(2300, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(9999, time.December, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(9999, time.December, 31, 23, 59, 59, 1, time.UTC),
+	}
+	for _, sortFunction := range SortFunctions {
+		slice := shuffledTimes(want)
+		sortFunction(TimeSortable(slice))
+		if !reflect.DeepEqual(slice, want) {
+			t.Errorf("got %v but want %v", slice, want)
+		}
+	}
+	slice := shuffledTimes(want)
+	RadixSortTimes(slice, TimeOrder{})
+	if !reflect.DeepEqual(slice, want) {
+		t.Errorf("radix sort: got %v but want %v", slice, want)
+	}
+}
+
+// TestMonotonicTimes tests that times with monotonic clock readings are
+// sorted by those readings.
+func TestMonotonicTimes(t *testing.T) {
+	now := time.Now()
+	want := []time.Time{now, now.Add(time.Nanosecond), now.Add(time.Second), now.Add(time.Hour)}
+	for _, sortFunction := range SortFunctions {
+		slice := shuffledTimes(want)
+		sortFunction(TimeSortable(slice))
+		for i := range want {
+			if !slice[i].Equal(want[i]) {
+				t.Errorf("got %v but want %v", slice, want)
+				break
+			}
+		}
+	}
+}
+
+// TestTimeOrder tests all sort functions and the radix sort with times in
+// different time zones and orders.
+func TestTimeOrder(t *testing.T) {
+	newYork := time.FixedZone("EST", -5*60*60)
+	berlin := time.FixedZone("CET", 1*60*60)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	// Instants in UTC: b 08:00, c 14:00, a 15:00, d 15:00 on March 2nd.
+	a := time.Date(2023, time.March, 2, 10, 0, 0, 0, newYork)
+	b := time.Date(2023, time.March, 2, 9, 0, 0, 0, berlin)
+	c := time.Date(2023, time.March, 2, 23, 0, 0, 0, tokyo)
+	d := time.Date(2023, time.March, 2, 15, 0, 0, 0, time.UTC)
+	input := []time.Time{a, b, c, d}
+
+	tests := map[string]struct {
+		order TimeOrder
+		want  []time.Time
+	}{
+		"instant": {
+			order: TimeOrder{},
+			want:  []time.Time{b, c, a, d},
+		},
+		"wall_clock_own_location": {
+			order: TimeOrder{WallClock: true},
+			want:  []time.Time{b, a, d, c},
+		},
+		"wall_clock_in_tokyo": {
+			order: TimeOrder{WallClock: true, Location: tokyo},
+			want:  []time.Time{b, c, a, d},
+		},
+		"date_only_in_tokyo": {
+			order: TimeOrder{DateOnly: true, Location: tokyo},
+			want:  []time.Time{b, c, a, d}, // b and c are on March 2nd, a and d on March 3rd
+		},
+	}
+	for name, test := range tests {
+		for _, sortFunction := range SortFunctions {
+			slice := append([]time.Time{}, input...)
+			sortFunction(test.order.Times(slice))
+			if !equalTimeKeys(slice, test.want, test.order) {
+				t.Errorf("%s: got %v but want %v", name, slice, test.want)
+			}
+		}
+		slice := append([]time.Time{}, input...)
+		RadixSortTimes(slice, test.order)
+		if !equalTimeKeys(slice, test.want, test.order) {
+			t.Errorf("%s: radix sort got %v but want %v", name, slice, test.want)
+		}
+	}
+}
+
+// TestRadixSortTimesStable tests that the radix sort keeps the order of times
+// of the same day.
+func TestRadixSortTimesStable(t *testing.T) {
+	day1 := time.Date(1500, time.May, 1, 23, 0, 0, 0, time.UTC)
+	day2 := time.Date(1500, time.May, 2, 1, 0, 0, 0, time.UTC)
+	slice := []time.Time{day2, day1, day2.Add(time.Hour), day1.Add(-time.Hour)}
+	want := []time.Time{day1, day1.Add(-time.Hour), day2, day2.Add(time.Hour)}
+	RadixSortTimes(slice, TimeOrder{DateOnly: true})
+	if !reflect.DeepEqual(slice, want) {
+		t.Errorf("got %v but want %v", slice, want)
+	}
+}
+
+// TestLargeRadixTimeSlice tests the radix sort with a large slice of random
+// times around the epoch.
+func TestLargeRadixTimeSlice(t *testing.T) {
+	slice := make([]time.Time, 10000)
+	for i := range slice {
+		slice[i] = time.Unix(rand.Int63n(1<<40)-1<<39, rand.Int63n(1e9))
+	}
+	RadixSortTimes(slice, TimeOrder{})
+	if !IsSorted(TimeSortable(slice)) {
+		t.Errorf("got unsorted data")
+	}
+}
+
+// shuffledTimes returns a shuffled copy of the specified times.
+func shuffledTimes(times []time.Time) []time.Time {
+	result := append([]time.Time{}, times...)
+	rand.Shuffle(len(result), func(i, j int) { result[i], result[j] = result[j], result[i] })
+	return result
+}
+
+// equalTimeKeys reports whether the specified slices are equal in the
+// specified order.
+func equalTimeKeys(got []time.Time, want []time.Time, order TimeOrder) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		gotSeconds, gotNanos := timeKey(got[i], order)
+		wantSeconds, wantNanos := timeKey(want[i], order)
+		if gotSeconds != wantSeconds || gotNanos != wantNanos {
+			return false
+		}
+	}
+	return true
+}
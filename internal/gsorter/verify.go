@@ -0,0 +1,147 @@
+package gsorter
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// VerifyEnv is the name of the environment variable that enables the checks
+// of VerifyingSorter in builds without the debug tag. Any non-empty value
+// enables them.
+const VerifyEnv = "SORTER_VERIFY"
+
+// VerificationError describes a sort result that failed verification.
+type VerificationError struct {
+	Index  int    // the first violating index or -1 if there is none
+	Reason string // a description of the violation
+}
+
+func (e *VerificationError) Error() string {
+	if e.Index < 0 {
+		return "sort verification failed: " + e.Reason
+	}
+	return fmt.Sprintf("sort verification failed at index %d: %s", e.Index, e.Reason)
+}
+
+// VerifyingSorter wraps a sort function and checks that its output is
+// sorted. The checks are done in builds with the debug tag or if the
+// environment variable VerifyEnv is set.
+type VerifyingSorter struct {
+	SortFunction func(sort.Interface)
+
+	// Hash returns a hash of the element at the specified index. If it is
+	// set, VerifyingSorter also checks that the output is a permutation of
+	// the input.
+	Hash func(i int) uint64
+}
+
+// Sort sorts the specified data using the wrapped sort function. If the
+// checks are enabled and fail, a *VerificationError is returned.
+func (v VerifyingSorter) Sort(data sort.Interface) error {
+	if !debugBuild && os.Getenv(VerifyEnv) == "" {
+		v.SortFunction(data)
+		return nil
+	}
+	var hash [2]uint64
+	if v.Hash != nil {
+		hash = multisetHash(data.Len(), v.Hash)
+	}
+	v.SortFunction(data)
+	if i := unsortedIndex(data); i >= 0 {
+		return &VerificationError{Index: i, Reason: "element is less than its predecessor"}
+	}
+	if v.Hash != nil && multisetHash(data.Len(), v.Hash) != hash {
+		return &VerificationError{Index: -1, Reason: "output is no permutation of the input"}
+	}
+	return nil
+}
+
+// IsSorted reports whether the specified data is sorted in ascending order.
+func IsSorted(data sort.Interface) bool {
+	return unsortedIndex(data) < 0
+}
+
+// IsStablySorted reports whether the specified data is sorted in ascending
+// order and equal elements are in the order of their original positions.
+// The original position of element i is indices[i].
+func IsStablySorted(data sort.Interface, indices []int) bool {
+	if data.Len() != len(indices) {
+		return false
+	}
+	for i := 1; i < len(indices); i++ {
+		if data.Less(i, i-1) || !data.Less(i-1, i) && indices[i] < indices[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPermutation reports whether the specified slices contain the same
+// elements with the same multiplicities. It counts the elements in a hash
+// map and runs in linear time. Unlike IsPermutationHash, it is exact and
+// needs no hash function, because the map can hash any comparable type, but
+// it needs memory for the distinct elements.
+func IsPermutation[T comparable](before []T, after []T) bool {
+	if len(before) != len(after) {
+		return false
+	}
+	counts := make(map[T]int, len(before))
+	for _, element := range before {
+		counts[element]++
+	}
+	for _, element := range after {
+		counts[element]--
+		if counts[element] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPermutationHash reports whether the specified slices contain the same
+// elements with the same multiplicities, where equal elements must have equal
+// hashes. It compares order-independent hashes of the two multisets, like
+// VerifyingSorter, so it needs no extra memory and runs in linear time. Each
+// multiset hash is the sum of two independent mixes of the element hashes.
+// Different multisets with distinct element hashes are reported as
+// permutations with a probability of about 2^-128, unless the elements are
+// chosen to collide.
+func IsPermutationHash[T any](before []T, after []T, hash func(T) uint64) bool {
+	return len(before) == len(after) &&
+		multisetHash(len(before), func(i int) uint64 { return hash(before[i]) }) ==
+			multisetHash(len(after), func(i int) uint64 { return hash(after[i]) })
+}
+
+// unsortedIndex returns the first index whose element is less than its
+// predecessor or -1 if the data is sorted.
+func unsortedIndex(data sort.Interface) int {
+	for i := 1; i < data.Len(); i++ {
+		if data.Less(i, i-1) {
+			return i
+		}
+	}
+	return -1
+}
+
+// multisetHash returns a hash of the elements with the specified element
+// hashes that does not depend on their order. It adds up two independent
+// derivations of every element hash, so that the result only depends on
+// which elements occur how often.
+func multisetHash(length int, hash func(i int) uint64) [2]uint64 {
+	var sum [2]uint64
+	for i := 0; i < length; i++ {
+		h := hash(i)
+		sum[0] += mix(h + 0x9e3779b97f4a7c15)
+		sum[1] += mix(h ^ 0xbf58476d1ce4e5b9)
+	}
+	return sum
+}
+
+// mix is the finalizer of the SplitMix64 generator, which spreads every input
+// bit over the whole result.
+func mix(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,213 @@
+package gsorter
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+// TestIsSorted tests the IsSorted function.
+func TestIsSorted(t *testing.T) {
+	tests := map[string]struct {
+		slice []string
+		want  bool
+	}{
+		"empty_input": {
+			slice: []string{},
+			want:  true,
+		},
+		"one_element": {
+			slice: []string{"foo"},
+			want:  true,
+		},
+		"repeating_elements": {
+			slice: []string{"a", "a", "b"},
+			want:  true,
+		},
+		"last_element_wrong": {
+			slice: []string{"a", "b", "c", "B"},
+			want:  false,
+		},
+	}
+	for name, test := range tests {
+		got := IsSorted(StringSortable(test.slice))
+		if got != test.want {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
+
+// TestIsStablySorted tests the IsStablySorted function.
+func TestIsStablySorted(t *testing.T) {
+	tests := map[string]struct {
+		slice   []int
+		indices []int
+		want    bool
+	}{
+		"empty_input": {
+			slice:   []int{},
+			indices: []int{},
+			want:    true,
+		},
+		"stable": {
+			slice:   []int{1, 4, 4, 4, 7},
+			indices: []int{3, 0, 2, 4, 1},
+			want:    true,
+		},
+		"unstable": {
+			slice:   []int{1, 4, 4, 4, 7},
+			indices: []int{3, 0, 4, 2, 1},
+			want:    false,
+		},
+		"unsorted": {
+			slice:   []int{4, 1},
+			indices: []int{0, 1},
+			want:    false,
+		},
+		"length_mismatch": {
+			slice:   []int{1, 2},
+			indices: []int{0},
+			want:    false,
+		},
+	}
+	for name, test := range tests {
+		got := IsStablySorted(IntSortable(test.slice), test.indices)
+		if got != test.want {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
+
+// TestIsPermutation tests the IsPermutation function.
+func TestIsPermutation(t *testing.T) {
+	tests := map[string]struct {
+		before []string
+		after  []string
+		want   bool
+	}{
+		"empty_input": {
+			before: []string{},
+			after:  []string{},
+			want:   true,
+		},
+		"reordered": {
+			before: []string{"c", "a", "c", "b"},
+			after:  []string{"a", "b", "c", "c"},
+			want:   true,
+		},
+		"other_multiplicity": {
+			before: []string{"a", "a", "b"},
+			after:  []string{"a", "b", "b"},
+			want:   false,
+		},
+		"length_mismatch": {
+			before: []string{"a"},
+			after:  []string{"a", "a"},
+			want:   false,
+		},
+	}
+	for name, test := range tests {
+		got := IsPermutation(test.before, test.after)
+		if got != test.want {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
+
+// TestIsPermutationHash tests the IsPermutationHash function.
+func TestIsPermutationHash(t *testing.T) {
+	hash := func(element int) uint64 { return uint64(element) }
+	tests := map[string]struct {
+		before []int
+		after  []int
+		want   bool
+	}{
+		"empty_input": {
+			before: []int{},
+			after:  []int{},
+			want:   true,
+		},
+		"reordered": {
+			before: []int{3, -1, 3, 2},
+			after:  []int{-1, 2, 3, 3},
+			want:   true,
+		},
+		"other_multiplicity": {
+			before: []int{1, 1, 2},
+			after:  []int{1, 2, 2},
+			want:   false,
+		},
+		"same_sum": {
+			before: []int{1, 4},
+			after:  []int{2, 3},
+			want:   false,
+		},
+		"length_mismatch": {
+			before: []int{1},
+			after:  []int{1, 1},
+			want:   false,
+		},
+	}
+	for name, test := range tests {
+		got := IsPermutationHash(test.before, test.after, hash)
+		if got != test.want {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
+
+// TestVerifyingSorter tests that VerifyingSorter reports broken sort
+// functions when the verification is enabled via the environment.
+func TestVerifyingSorter(t *testing.T) {
+	t.Setenv(VerifyEnv, "1")
+	tests := map[string]struct {
+		sortFunction func(data IntSortable)
+		wantIndex    int // -1 for a permutation error, -2 for no error
+	}{
+		"correct": {
+			sortFunction: func(data IntSortable) { QuickSort(data) },
+			wantIndex:    -2,
+		},
+		"not_sorting": {
+			sortFunction: func(data IntSortable) {},
+			wantIndex:    1,
+		},
+		"losing_elements": {
+			sortFunction: func(data IntSortable) {
+				QuickSort(data)
+				data[0] = data[1]
+			},
+			wantIndex: -1,
+		},
+	}
+	for name, test := range tests {
+		data := IntSortable{5, 1, 4, 2, 3}
+		sorter := VerifyingSorter{
+			SortFunction: func(data sort.Interface) { test.sortFunction(data.(IntSortable)) },
+			Hash:         func(i int) uint64 { return uint64(data[i]) },
+		}
+		err := sorter.Sort(data)
+		var verificationError *VerificationError
+		switch {
+		case test.wantIndex == -2 && err != nil:
+			t.Errorf("%s: got error %v but want none", name, err)
+		case test.wantIndex != -2 && !errors.As(err, &verificationError):
+			t.Errorf("%s: got error %v but want a *VerificationError", name, err)
+		case test.wantIndex != -2 && verificationError.Index != test.wantIndex:
+			t.Errorf("%s: got index %v but want %v", name, verificationError.Index, test.wantIndex)
+		}
+	}
+}
+
+// TestVerifyingSorterDisabled tests that VerifyingSorter skips the checks if
+// they are not enabled.
+func TestVerifyingSorterDisabled(t *testing.T) {
+	if debugBuild {
+		t.Skip("checks are always enabled in debug builds")
+	}
+	t.Setenv(VerifyEnv, "")
+	sorter := VerifyingSorter{SortFunction: func(data sort.Interface) {}}
+	if err := sorter.Sort(IntSortable{2, 1}); err != nil {
+		t.Errorf("got error %v but want none", err)
+	}
+}
@@ -0,0 +1,59 @@
+package merge
+
+// heap is a binary min-heap of the indexes of the sources, ordered by their
+// current values. Exhausted sources are removed from the heap, except for the
+// last one, which marks the end of the merge.
+type heap[T any] struct {
+	indexes []int
+	sources *sources[T]
+}
+
+// newHeap returns a heap for the specified sources, which must have pulled
+// their first values.
+func newHeap[T any](sources *sources[T]) *heap[T] {
+	h := &heap[T]{sources: sources}
+	for i, done := range sources.done {
+		if !done {
+			h.indexes = append(h.indexes, i)
+		}
+	}
+	if len(h.indexes) == 0 && len(sources.done) > 0 {
+		h.indexes = append(h.indexes, 0)
+	}
+	for i := len(h.indexes)/2 - 1; i >= 0; i-- {
+		h.siftDown(i)
+	}
+	return h
+}
+
+func (h *heap[T]) winner() int {
+	return h.indexes[0]
+}
+
+func (h *heap[T]) update(winner int) {
+	if h.sources.done[winner] && len(h.indexes) > 1 {
+		last := len(h.indexes) - 1
+		h.indexes[0] = h.indexes[last]
+		h.indexes = h.indexes[:last]
+	}
+	h.siftDown(0)
+}
+
+// siftDown moves the source at the specified position of the heap down until
+// none of its children comes before it.
+func (h *heap[T]) siftDown(i int) {
+	for {
+		child := 2*i + 1
+		if child >= len(h.indexes) {
+			return
+		}
+		if child+1 < len(h.indexes) && h.sources.before(h.indexes[child+1], h.indexes[child]) {
+			child++
+		}
+		if !h.sources.before(h.indexes[child], h.indexes[i]) {
+			return
+		}
+		h.indexes[i], h.indexes[child] = h.indexes[child], h.indexes[i]
+		i = child
+	}
+}
@@ -0,0 +1,183 @@
+package merge
+
+import (
+	"cmp"
+
+	"gitlab.com/dirk.krummacker/sorter/internal/gsorter"
+)
+
+// Strategy selects how a Merger finds the source with the smallest value.
+type Strategy int
+
+const (
+	// TournamentTree keeps the losers of all matches in a tree, so that
+	// replacing the winner takes exactly log2(k) comparisons for k sources.
+	TournamentTree Strategy = iota
+
+	// BinaryHeap keeps the sources in a heap ordered by their values, which
+	// needs up to 2*log2(k) comparisons per value.
+	BinaryHeap
+)
+
+// Merger merges sorted sources into one sorted output. Values that are equal
+// according to the comparator are taken from the sources in the order of the
+// sources, so merging is stable. The sources must be sorted in the order of
+// the comparator.
+type Merger[T any] struct {
+	Comparator gsorter.Comparator[T]
+	Strategy   Strategy
+}
+
+// Slices merges the specified sorted slices into a new sorted slice in
+// ascending order.
+func Slices[T cmp.Ordered](slices ...[]T) []T {
+	return Merger[T]{Comparator: cmp.Compare[T]}.Slices(slices...)
+}
+
+// Channels merges the values received from the specified sorted channels
+// into the returned channel in ascending order, see Merger.Channels.
+func Channels[T cmp.Ordered](channels ...<-chan T) <-chan T {
+	return Merger[T]{Comparator: cmp.Compare[T]}.Channels(channels...)
+}
+
+// Pulls merges the values returned by the specified sorted pull functions in
+// ascending order, see Merger.Pulls.
+func Pulls[T cmp.Ordered](pulls ...func() (T, bool)) func() (T, bool) {
+	return Merger[T]{Comparator: cmp.Compare[T]}.Pulls(pulls...)
+}
+
+// Slices merges the specified sorted slices into a new sorted slice.
+func (m Merger[T]) Slices(slices ...[]T) []T {
+	total := 0
+	for _, slice := range slices {
+		total += len(slice)
+	}
+	positions := make([]int, len(slices))
+	sources := &sources[T]{comparator: m.Comparator, pull: func(i int) (T, bool) {
+		if positions[i] == len(slices[i]) {
+			var zero T
+			return zero, false
+		}
+		positions[i]++
+		return slices[i][positions[i]-1], true
+	}}
+	result := make([]T, 0, total)
+	next := m.merge(sources, len(slices))
+	for value, ok := next(); ok; value, ok = next() {
+		result = append(result, value)
+	}
+	return result
+}
+
+// Channels merges the values received from the specified sorted channels
+// into the returned channel, which is closed after all of the specified
+// channels have been closed. The merge runs in a goroutine that only ends
+// when the returned channel has been drained.
+func (m Merger[T]) Channels(channels ...<-chan T) <-chan T {
+	pulls := make([]func() (T, bool), len(channels))
+	for i, channel := range channels {
+		pulls[i] = channelPull(channel)
+	}
+	result := make(chan T)
+	go func() {
+		defer close(result)
+		next := m.Pulls(pulls...)
+		for value, ok := next(); ok; value, ok = next() {
+			result <- value
+		}
+	}()
+	return result
+}
+
+// Pulls merges the values returned by the specified sorted pull functions. A
+// pull function returns the next value of its source and true, or false if
+// the source is exhausted. The returned pull function works the same way.
+// The sources are first pulled from when the returned function is first
+// called.
+func (m Merger[T]) Pulls(pulls ...func() (T, bool)) func() (T, bool) {
+	sources := &sources[T]{comparator: m.Comparator, pull: func(i int) (T, bool) { return pulls[i]() }}
+	return m.merge(sources, len(pulls))
+}
+
+// merge returns a pull function that merges the specified number of sources.
+func (m Merger[T]) merge(sources *sources[T], count int) func() (T, bool) {
+	var s selector
+	return func() (T, bool) {
+		if s == nil {
+			sources.start(count)
+			if m.Strategy == BinaryHeap {
+				s = newHeap(sources)
+			} else {
+				s = newTournamentTree(sources)
+			}
+		}
+		var zero T
+		if count == 0 {
+			return zero, false
+		}
+		winner := s.winner()
+		if sources.done[winner] {
+			return zero, false
+		}
+		value := sources.heads[winner]
+		sources.advance(winner)
+		s.update(winner)
+		return value, true
+	}
+}
+
+// channelPull returns a pull function for the specified channel.
+func channelPull[T any](channel <-chan T) func() (T, bool) {
+	return func() (T, bool) {
+		value, ok := <-channel
+		return value, ok
+	}
+}
+
+// sources holds the current value of every source of a merge.
+type sources[T any] struct {
+	pull       func(i int) (T, bool) // returns the next value of a source
+	comparator gsorter.Comparator[T]
+	heads      []T    // the current value of every source
+	done       []bool // whether a source is exhausted
+}
+
+// start pulls the first value of the specified number of sources.
+func (s *sources[T]) start(count int) {
+	s.heads = make([]T, count)
+	s.done = make([]bool, count)
+	for i := 0; i < count; i++ {
+		s.advance(i)
+	}
+}
+
+// advance pulls the next value of the specified source.
+func (s *sources[T]) advance(i int) {
+	value, ok := s.pull(i)
+	s.heads[i], s.done[i] = value, !ok
+}
+
+// before reports whether the current value of source a comes before the one
+// of source b. Exhausted sources come after all others, and equal values are
+// ordered by the index of the source.
+func (s *sources[T]) before(a int, b int) bool {
+	switch {
+	case s.done[a]:
+		return false
+	case s.done[b]:
+		return true
+	}
+	if result := s.comparator.Compare(s.heads[a], s.heads[b]); result != 0 {
+		return result < 0
+	}
+	return a < b
+}
+
+// selector finds the source with the smallest current value.
+type selector interface {
+	// winner returns the index of the source with the smallest value.
+	winner() int
+
+	// update restores the order after the value of the winner changed.
+	update(winner int)
+}
@@ -0,0 +1,193 @@
+package merge
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gitlab.com/dirk.krummacker/sorter/internal/gsorter"
+	"gitlab.com/dirk.krummacker/sorter/internal/sorter"
+)
+
+// strategies are all strategies of Merger.
+var strategies = map[string]Strategy{
+	"tournament": TournamentTree,
+	"heap":       BinaryHeap,
+}
+
+// sortedShards returns the specified number of sorted random shards with
+// random lengths of up to the specified length, and their sorted
+// concatenation.
+func sortedShards(count int, maxLength int, maxValue int) ([][]int, []int) {
+	shards := make([][]int, count)
+	var all []int
+	for i := range shards {
+		shards[i] = make([]int, rand.Intn(maxLength+1))
+		for j := range shards[i] {
+			shards[i][j] = rand.Intn(maxValue)
+		}
+		sort.Ints(shards[i])
+		all = append(all, shards[i]...)
+	}
+	sort.Ints(all)
+	if all == nil {
+		all = []int{}
+	}
+	return shards, all
+}
+
+// TestSlices tests merging slices with all strategies and numbers of shards.
+func TestSlices(t *testing.T) {
+	for _, count := range []int{0, 1, 2, 3, 7, 64} {
+		for _, maxLength := range []int{0, 1, 100} {
+			shards, want := sortedShards(count, maxLength, 50)
+			for strategyName, strategy := range strategies {
+				name := fmt.Sprintf("shards=%d/length=%d/%s", count, maxLength, strategyName)
+				got := Merger[int]{Comparator: func(a, b int) int { return a - b }, Strategy: strategy}.Slices(shards...)
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%s: got %v but want %v", name, got, want)
+				}
+			}
+			if got := Slices(shards...); !reflect.DeepEqual(got, want) {
+				t.Errorf("shards=%d/length=%d: got %v but want %v", count, maxLength, got, want)
+			}
+		}
+	}
+}
+
+// item is a value with the shard it comes from.
+type item struct {
+	key   int
+	shard int
+}
+
+// TestStable tests that equal values are taken from the shards in the order
+// of the shards.
+func TestStable(t *testing.T) {
+	shards := make([][]item, 9)
+	var want []item
+	for i := range shards {
+		for key := 0; key < 20; key += 1 + rand.Intn(3) {
+			shards[i] = append(shards[i], item{key, i})
+			want = append(want, item{key, i})
+		}
+	}
+	sort.SliceStable(want, func(i, j int) bool { return want[i].key < want[j].key })
+	byKey := gsorter.By(func(i item) int { return i.key })
+	for strategyName, strategy := range strategies {
+		got := Merger[item]{Comparator: byKey, Strategy: strategy}.Slices(shards...)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v but want %v", strategyName, got, want)
+		}
+	}
+
+	// Shards that are exhausted at different times must not change the
+	// order of the remaining ones.
+	shards = [][]item{{{1, 0}, {2, 0}}, {{1, 1}, {2, 1}}, {{1, 2}}, {{2, 3}}, {{1, 4}, {2, 4}}, {{1, 5}}, {{2, 6}}}
+	want = []item{{1, 0}, {1, 1}, {1, 2}, {1, 4}, {1, 5}, {2, 0}, {2, 1}, {2, 3}, {2, 4}, {2, 6}}
+	for strategyName, strategy := range strategies {
+		got := Merger[item]{Comparator: byKey, Strategy: strategy}.Slices(shards...)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s with exhausted shards: got %v but want %v", strategyName, got, want)
+		}
+	}
+}
+
+// TestChannels tests merging channels that are filled concurrently.
+func TestChannels(t *testing.T) {
+	shards, want := sortedShards(5, 1000, 1000)
+	for strategyName, strategy := range strategies {
+		channels := make([]<-chan int, len(shards))
+		for i, shard := range shards {
+			channel := make(chan int, rand.Intn(10))
+			channels[i] = channel
+			go func(shard []int) {
+				defer close(channel)
+				for _, value := range shard {
+					channel <- value
+				}
+			}(shard)
+		}
+		got := []int{}
+		for value := range (Merger[int]{Comparator: func(a, b int) int { return a - b }, Strategy: strategy}).Channels(channels...) {
+			got = append(got, value)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v but want %v", strategyName, got, want)
+		}
+	}
+	if _, ok := <-Channels[int](); ok {
+		t.Errorf("no channels: got a value")
+	}
+}
+
+// TestPulls tests merging pull functions lazily.
+func TestPulls(t *testing.T) {
+	pulled := 0
+	counter := func(from, to, step int) func() (int, bool) {
+		next := from
+		return func() (int, bool) {
+			if next >= to {
+				return 0, false
+			}
+			pulled++
+			next += step
+			return next - step, true
+		}
+	}
+	next := Pulls(counter(0, 10, 3), counter(1, 10, 3), counter(2, 10, 3))
+	if pulled != 0 {
+		t.Errorf("got %d values pulled before the first call", pulled)
+	}
+	got := []int{}
+	for value, ok := next(); ok; value, ok = next() {
+		got = append(got, value)
+	}
+	if want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v but want %v", got, want)
+	}
+	if _, ok := next(); ok {
+		t.Errorf("got a value after the end")
+	}
+}
+
+// BenchmarkMerge compares merging sorted shards with concatenating and
+// sorting them for different numbers of shards.
+func BenchmarkMerge(b *testing.B) {
+	const size = 100000
+	for _, count := range []int{2, 8, 64} {
+		shards := make([][]int, count)
+		for i := range shards {
+			shards[i] = sorter.CreateRandomInts(size / count)
+			sort.Ints(shards[i])
+		}
+		for strategyName, strategy := range strategies {
+			merger := Merger[int]{Comparator: func(a, b int) int { return a - b }, Strategy: strategy}
+			b.Run(fmt.Sprintf("shards=%d/method=%s", count, strategyName), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					merger.Slices(shards...)
+				}
+			})
+		}
+		b.Run(fmt.Sprintf("shards=%d/method=quicksort", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				all := make([]int, 0, size)
+				for _, shard := range shards {
+					all = append(all, shard...)
+				}
+				sorter.QuickSort(all)
+			}
+		})
+		b.Run(fmt.Sprintf("shards=%d/method=generic_quicksort", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				all := make([]int, 0, size)
+				for _, shard := range shards {
+					all = append(all, shard...)
+				}
+				gsorter.QuickSort(gsorter.IntSortable(all))
+			}
+		})
+	}
+}
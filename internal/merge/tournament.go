@@ -0,0 +1,45 @@
+package merge
+
+// tournamentTree is a loser tree. Every inner node holds the source that lost
+// the match at that node, and the root holds the overall winner. The leaves
+// are at the indexes k to 2k-1 of the implicit tree for k sources.
+type tournamentTree[T any] struct {
+	nodes   []int // nodes[0] is the winner, nodes[1:] are the losers
+	sources *sources[T]
+}
+
+// newTournamentTree returns a tournament tree for the specified sources,
+// which must have pulled their first values.
+func newTournamentTree[T any](sources *sources[T]) *tournamentTree[T] {
+	k := len(sources.heads)
+	t := &tournamentTree[T]{nodes: make([]int, k), sources: sources}
+	winners := make([]int, 2*k)
+	for i := 0; i < k; i++ {
+		winners[k+i] = i
+	}
+	for node := k - 1; node >= 1; node-- {
+		a, b := winners[2*node], winners[2*node+1]
+		if sources.before(b, a) {
+			a, b = b, a
+		}
+		winners[node], t.nodes[node] = a, b
+	}
+	if k > 0 {
+		t.nodes[0] = winners[1]
+	}
+	return t
+}
+
+func (t *tournamentTree[T]) winner() int {
+	return t.nodes[0]
+}
+
+func (t *tournamentTree[T]) update(source int) {
+	winner := source
+	for node := (source + len(t.nodes)) / 2; node >= 1; node /= 2 {
+		if t.sources.before(t.nodes[node], winner) {
+			t.nodes[node], winner = winner, t.nodes[node]
+		}
+	}
+	t.nodes[0] = winner
+}
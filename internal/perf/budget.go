@@ -0,0 +1,74 @@
+package perf
+
+import "time"
+
+// Budget decides which algorithms are measured at each size of a scaling run
+// and estimates the durations that are not measured. An algorithm is skipped
+// at all larger sizes once one of its sorts exceeds the limit, so that slow
+// algorithms do not hang the run. The durations of the skipped columns are
+// extrapolated from the best-fitting complexity class of their measurements.
+type Budget struct {
+	limit       time.Duration
+	minDuration float64              // shorter measurements are not fitted
+	exceeded    map[string]bool      // the algorithms that are skipped
+	sizes       map[string][]int     // the fitted sizes per column
+	durations   map[string][]float64 // the fitted durations per column
+}
+
+// NewBudget returns a budget with the specified time limit for a single sort.
+// Average durations below the specified number of microseconds are dominated
+// by noise and not used for extrapolation.
+func NewBudget(limit time.Duration, minDuration float64) *Budget {
+	return &Budget{
+		limit:       limit,
+		minDuration: minDuration,
+		exceeded:    make(map[string]bool),
+		sizes:       make(map[string][]int),
+		durations:   make(map[string][]float64),
+	}
+}
+
+// Skip reports whether the specified algorithm has exceeded the limit and is
+// no longer measured.
+func (b *Budget) Skip(algorithm string) bool {
+	return b.exceeded[algorithm]
+}
+
+// Charge records the durations in microseconds of single sorts of the
+// specified algorithm. If one of them exceeds the limit, the algorithm is
+// skipped from then on.
+func (b *Budget) Charge(algorithm string, durations ...int) {
+	for _, duration := range durations {
+		if duration > int(b.limit.Microseconds()) {
+			b.exceeded[algorithm] = true
+		}
+	}
+}
+
+// Observe records the average duration in microseconds of the specified
+// column of the result table at the specified size.
+func (b *Budget) Observe(column string, size int, duration float64) {
+	if duration < b.minDuration {
+		return
+	}
+	b.sizes[column] = append(b.sizes[column], size)
+	b.durations[column] = append(b.durations[column], duration)
+}
+
+// Extrapolate returns the duration in microseconds that the best-fitting
+// complexity class of the observed durations of the specified column predicts
+// for the specified size. The boolean result is false if there is not enough
+// data for a prediction.
+func (b *Budget) Extrapolate(column string, size int) (float64, bool) {
+	fit, ok := b.Fit(column)
+	if !ok {
+		return 0, false
+	}
+	return fit.Estimate(size), true
+}
+
+// Fit returns the best-fitting complexity class of the observed durations of
+// the specified column, see BestFit.
+func (b *Budget) Fit(column string) (Fit, bool) {
+	return BestFit(b.sizes[column], b.durations[column])
+}
@@ -0,0 +1,71 @@
+package perf
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestBudget tests the Budget with synthetic scaling runs of one algorithm.
+// Each step charges and observes the durations at a size, unless the
+// algorithm is skipped, and then reports the cell of the result table as the
+// measured duration, an extrapolated one ("~") or "timeout".
+func TestBudget(t *testing.T) {
+	sizes := []int{1000, 2000, 4000, 8000, 16000}
+	tests := map[string]struct {
+		durations []int // the duration at each size if measured
+		want      []string
+	}{
+		"within_budget": {
+			durations: []int{100, 200, 400, 800, 1600},
+			want:      []string{"100", "200", "400", "800", "1600"},
+		},
+		"extrapolated": {
+			durations: []int{100, 400, 1600, 6400, 25600},
+			want:      []string{"100", "400", "1600", "6400", "~25600"},
+		},
+		"too_few_points": {
+			durations: []int{100, 6400, 25600, 102400, 409600},
+			want:      []string{"100", "6400", "timeout", "timeout", "timeout"},
+		},
+		"too_short": {
+			durations: []int{1, 5, 10, 6400, 25600},
+			want:      []string{"1", "5", "10", "6400", "timeout"},
+		},
+		"first_size": {
+			durations: []int{8000, 16000, 32000, 64000, 128000},
+			want:      []string{"8000", "timeout", "timeout", "timeout", "timeout"},
+		},
+	}
+	for name, test := range tests {
+		budget := NewBudget(5*time.Millisecond, 20)
+		for i, size := range sizes {
+			got := "timeout"
+			if !budget.Skip("Algorithm") {
+				budget.Charge("Algorithm", test.durations[i])
+				budget.Observe("Algorithm/u", size, float64(test.durations[i]))
+				got = strconv.Itoa(test.durations[i])
+			} else if estimate, ok := budget.Extrapolate("Algorithm/u", size); ok {
+				got = "~" + strconv.Itoa(int(math.Round(estimate)))
+			}
+			if got != test.want[i] {
+				t.Errorf("%s: got %s at size %d but want %s", name, got, size, test.want[i])
+			}
+		}
+	}
+}
+
+// TestBudgetCharge tests that an algorithm is skipped if any of the charged
+// durations exceeds the limit, and that other algorithms are not affected.
+func TestBudgetCharge(t *testing.T) {
+	budget := NewBudget(time.Millisecond, 20)
+	budget.Charge("Fast", 999, 1000)
+	budget.Charge("Slow", 10, 1001)
+	if budget.Skip("Fast") {
+		t.Errorf("got Fast skipped but want it measured")
+	}
+	if !budget.Skip("Slow") {
+		t.Errorf("got Slow measured but want it skipped")
+	}
+}
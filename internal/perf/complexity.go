@@ -0,0 +1,89 @@
+package perf
+
+import (
+	"math"
+)
+
+// Model is a candidate complexity class that measured timings can be fitted
+// against.
+type Model struct {
+	Name string
+	F    func(n float64) float64
+}
+
+// The complexity classes that are considered when fitting timings.
+var (
+	Linear           = Model{"n", func(n float64) float64 { return n }}
+	Linearithmic     = Model{"n log n", func(n float64) float64 { return n * math.Log2(n) }}
+	LinearLogSquared = Model{"n log^2 n", func(n float64) float64 { return n * math.Log2(n) * math.Log2(n) }}
+	Quadratic        = Model{"n^2", func(n float64) float64 { return n * n }}
+)
+
+// Models is a slice of all complexity classes that are considered by Fit.
+var Models = []Model{
+	Linear,
+	Linearithmic,
+	LinearLogSquared,
+	Quadratic,
+}
+
+// MinFitPoints is the minimum number of usable measurements that Fit needs
+// before it reports a result.
+const MinFitPoints = 3
+
+// Fit is the result of fitting measurements against a complexity model.
+type Fit struct {
+	Model    Model
+	Constant float64 // the factor c in t(n) = c * f(n)
+	Error    float64 // standard deviation of the logarithmic residuals
+}
+
+// Estimate returns the time that the fitted model predicts for size n.
+func (f Fit) Estimate(n int) float64 {
+	return f.Constant * f.Model.F(float64(n))
+}
+
+// FitModel fits the specified measurements against the specified model. The
+// fit is done in logarithmic space so that large sizes do not dominate the
+// result. Measurements with a non-positive size or value are ignored. The
+// boolean result is false if there are fewer than MinFitPoints usable
+// measurements.
+func FitModel(model Model, sizes []int, values []float64) (Fit, bool) {
+	var residuals []float64
+	for i, size := range sizes {
+		if i >= len(values) || size < 2 || values[i] <= 0 {
+			continue
+		}
+		residuals = append(residuals, math.Log(values[i]/model.F(float64(size))))
+	}
+	if len(residuals) < MinFitPoints {
+		return Fit{}, false
+	}
+	mean := 0.0
+	for _, r := range residuals {
+		mean += r
+	}
+	mean /= float64(len(residuals))
+	variance := 0.0
+	for _, r := range residuals {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(residuals))
+	return Fit{Model: model, Constant: math.Exp(mean), Error: math.Sqrt(variance)}, true
+}
+
+// BestFit fits the specified measurements against all Models and returns the
+// one with the smallest error. The boolean result is false if there are not
+// enough usable measurements.
+func BestFit(sizes []int, values []float64) (Fit, bool) {
+	var best Fit
+	found := false
+	for _, model := range Models {
+		fit, ok := FitModel(model, sizes, values)
+		if ok && (!found || fit.Error < best.Error) {
+			best = fit
+			found = true
+		}
+	}
+	return best, found
+}
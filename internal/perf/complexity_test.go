@@ -0,0 +1,84 @@
+package perf
+
+import (
+	"math"
+	"testing"
+)
+
+// TestBestFit tests the BestFit function with synthetic measurements.
+func TestBestFit(t *testing.T) {
+	sizes := []int{1000, 5000, 10000, 50000, 100000, 500000, 1000000}
+	tests := map[string]struct {
+		model    Model
+		constant float64
+	}{
+		"linear": {
+			model:    Linear,
+			constant: 2,
+		},
+		"linearithmic": {
+			model:    Linearithmic,
+			constant: 0.05,
+		},
+		"linear_log_squared": {
+			model:    LinearLogSquared,
+			constant: 0.3,
+		},
+		"quadratic": {
+			model:    Quadratic,
+			constant: 0.001,
+		},
+	}
+	for name, test := range tests {
+		values := make([]float64, len(sizes))
+		for i, size := range sizes {
+			values[i] = test.constant * test.model.F(float64(size))
+		}
+		got, ok := BestFit(sizes, values)
+		if !ok {
+			t.Errorf("%s: got no fit", name)
+			continue
+		}
+		if got.Model.Name != test.model.Name {
+			t.Errorf("%s: got model %v but want %v", name, got.Model.Name, test.model.Name)
+		}
+		if math.Abs(got.Constant-test.constant) > test.constant*1e-9 {
+			t.Errorf("%s: got constant %v but want %v", name, got.Constant, test.constant)
+		}
+	}
+}
+
+// TestBestFitInsufficientData tests that BestFit ignores unusable
+// measurements and refuses to fit too few of them.
+func TestBestFitInsufficientData(t *testing.T) {
+	tests := map[string]struct {
+		sizes  []int
+		values []float64
+	}{
+		"empty_input": {
+			sizes:  []int{},
+			values: []float64{},
+		},
+		"two_points": {
+			sizes:  []int{10, 100},
+			values: []float64{1, 10},
+		},
+		"zero_values": {
+			sizes:  []int{10, 100, 1000, 10000},
+			values: []float64{0, 0, 10, 100},
+		},
+	}
+	for name, test := range tests {
+		if got, ok := BestFit(test.sizes, test.values); ok {
+			t.Errorf("%s: got %v but want no fit", name, got)
+		}
+	}
+}
+
+// TestEstimate tests the Estimate method.
+func TestEstimate(t *testing.T) {
+	fit := Fit{Model: Quadratic, Constant: 0.5}
+	if got := fit.Estimate(100); got != 5000 {
+		t.Errorf("got %v but want %v", got, 5000)
+	}
+}
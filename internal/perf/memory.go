@@ -0,0 +1,45 @@
+package perf
+
+import (
+	"runtime"
+)
+
+// MemUsage holds the memory statistics of a measured function call. The
+// fields are floating point numbers so that they can hold averages.
+type MemUsage struct {
+	Bytes  float64 `json:"bytes"`  // bytes allocated on the heap
+	Allocs float64 `json:"allocs"` // number of heap allocations
+	GCs    float64 `json:"gcs"`    // number of completed garbage collection cycles
+}
+
+// MeasureMemory executes the specified function and returns the memory it
+// allocated and the garbage collection cycles that happened in between. The
+// statistics are read outside of the function call, so that any timing done
+// inside of it is not affected.
+func MeasureMemory(function func()) MemUsage {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	function()
+	runtime.ReadMemStats(&after)
+	return MemUsage{
+		Bytes:  float64(after.TotalAlloc - before.TotalAlloc),
+		Allocs: float64(after.Mallocs - before.Mallocs),
+		GCs:    float64(after.NumGC - before.NumGC),
+	}
+}
+
+// AverageMemUsage returns the average of the specified memory statistics or
+// the zero value if there are no elements.
+func AverageMemUsage(input []MemUsage) MemUsage {
+	if len(input) == 0 {
+		return MemUsage{}
+	}
+	var sum MemUsage
+	for _, element := range input {
+		sum.Bytes += element.Bytes
+		sum.Allocs += element.Allocs
+		sum.GCs += element.GCs
+	}
+	n := float64(len(input))
+	return MemUsage{Bytes: sum.Bytes / n, Allocs: sum.Allocs / n, GCs: sum.GCs / n}
+}
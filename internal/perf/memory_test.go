@@ -0,0 +1,50 @@
+package perf
+
+import (
+	"reflect"
+	"testing"
+)
+
+// sink keeps allocations in TestMeasureMemory from being optimized away.
+var sink []byte
+
+// TestMeasureMemory tests that MeasureMemory sees allocations.
+func TestMeasureMemory(t *testing.T) {
+	got := MeasureMemory(func() {
+		sink = make([]byte, 1<<20)
+	})
+	if got.Bytes < 1<<20 || got.Allocs < 1 {
+		t.Errorf("got %v but want at least %v bytes in 1 allocation", got, 1<<20)
+	}
+	got = MeasureMemory(func() {})
+	if got.Bytes != 0 || got.Allocs != 0 {
+		t.Errorf("got %v but want no allocations", got)
+	}
+}
+
+// TestAverageMemUsage tests the AverageMemUsage function.
+func TestAverageMemUsage(t *testing.T) {
+	tests := map[string]struct {
+		input []MemUsage
+		want  MemUsage
+	}{
+		"zero_size": {
+			input: []MemUsage{},
+			want:  MemUsage{},
+		},
+		"size_one": {
+			input: []MemUsage{{Bytes: 64, Allocs: 2, GCs: 1}},
+			want:  MemUsage{Bytes: 64, Allocs: 2, GCs: 1},
+		},
+		"size_many": {
+			input: []MemUsage{{Bytes: 64, Allocs: 2, GCs: 1}, {Bytes: 0, Allocs: 0, GCs: 0}},
+			want:  MemUsage{Bytes: 32, Allocs: 1, GCs: 0.5},
+		},
+	}
+	for name, test := range tests {
+		got := AverageMemUsage(test.input)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
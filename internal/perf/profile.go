@@ -0,0 +1,71 @@
+package perf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// ProfileOptions specifies which profiles are written by Profile. Empty file
+// names disable the corresponding profile.
+type ProfileOptions struct {
+	CPUProfile string // file for the pprof CPU profile
+	MemProfile string // file for the pprof heap profile
+	Trace      string // file for the execution trace
+}
+
+// Enabled reports whether at least one profile is to be written.
+func (o ProfileOptions) Enabled() bool {
+	return o.CPUProfile != "" || o.MemProfile != "" || o.Trace != ""
+}
+
+// Profile executes the specified function while recording the profiles
+// selected by the specified options. The profile files are closed before it
+// returns, and errors from closing them are returned, too.
+func Profile(options ProfileOptions, function func()) (err error) {
+	// The result is not shadowed by the errors of creating the files, so the
+	// deferred closes can join their errors to it.
+	if options.CPUProfile != "" {
+		var file *os.File
+		file, err = os.Create(options.CPUProfile)
+		if err != nil {
+			return fmt.Errorf("cannot create CPU profile: %w", err)
+		}
+		defer func() { err = errors.Join(err, file.Close()) }()
+		if err := pprof.StartCPUProfile(file); err != nil {
+			return fmt.Errorf("cannot start CPU profile: %w", err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+	if options.Trace != "" {
+		var file *os.File
+		file, err = os.Create(options.Trace)
+		if err != nil {
+			return fmt.Errorf("cannot create trace: %w", err)
+		}
+		defer func() { err = errors.Join(err, file.Close()) }()
+		if err := trace.Start(file); err != nil {
+			return fmt.Errorf("cannot start trace: %w", err)
+		}
+		defer trace.Stop()
+	}
+
+	function()
+
+	if options.MemProfile != "" {
+		var file *os.File
+		file, err = os.Create(options.MemProfile)
+		if err != nil {
+			return fmt.Errorf("cannot create memory profile: %w", err)
+		}
+		defer func() { err = errors.Join(err, file.Close()) }()
+		runtime.GC() // get up-to-date statistics
+		if err := pprof.Lookup("allocs").WriteTo(file, 0); err != nil {
+			return fmt.Errorf("cannot write memory profile: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package perf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestProfile tests that Profile runs the function and writes all three
+// profiles.
+func TestProfile(t *testing.T) {
+	dir := t.TempDir()
+	options := ProfileOptions{
+		CPUProfile: filepath.Join(dir, "cpu.pprof"),
+		MemProfile: filepath.Join(dir, "mem.pprof"),
+		Trace:      filepath.Join(dir, "trace.out"),
+	}
+	called := false
+	err := Profile(options, func() {
+		called = true
+		sink = make([]byte, 1<<20)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Errorf("got the function not called")
+	}
+	for _, name := range []string{options.CPUProfile, options.MemProfile, options.Trace} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Errorf("%s: got %v", filepath.Base(name), err)
+		} else if info.Size() == 0 {
+			t.Errorf("%s: got an empty file", filepath.Base(name))
+		}
+	}
+}
+
+// TestProfileCreateError tests that Profile fails if a profile cannot be
+// created.
+func TestProfileCreateError(t *testing.T) {
+	options := ProfileOptions{MemProfile: filepath.Join(t.TempDir(), "missing", "mem.pprof")}
+	if err := Profile(options, func() {}); err == nil {
+		t.Errorf("got no error")
+	}
+}
@@ -0,0 +1,57 @@
+package perf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// The cases that are measured for every algorithm and size.
+const (
+	Unsorted = "unsorted" // random input
+	Sorted   = "sorted"   // input that has already been sorted
+)
+
+// Report is the machine-readable result of a run of the perf tools.
+type Report struct {
+	Tool     string   `json:"tool"`     // name of the program that created the report
+	Baseline string   `json:"baseline"` // algorithm that speedups are relative to
+	Loops    int      `json:"loops"`    // number of measurements per result
+	Results  []Result `json:"results"`
+}
+
+// Result is the average measurement of an algorithm for one case and size.
+type Result struct {
+	Algorithm    string   `json:"algorithm"`
+	Case         string   `json:"case"`
+	Size         int      `json:"size"`
+	Micros       float64  `json:"micros"`                 // average duration of a sort
+	Extrapolated bool     `json:"extrapolated,omitempty"` // duration is predicted, not measured
+	Memory       MemUsage `json:"memory"`
+}
+
+// WriteReport writes the specified report as indented JSON to the file with
+// the specified name.
+func WriteReport(name string, report Report) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("cannot create report: %w", err)
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		file.Close()
+		return fmt.Errorf("cannot write report: %w", err)
+	}
+	return file.Close()
+}
+
+// ReadReport reads a report in JSON format from the specified reader.
+func ReadReport(reader io.Reader) (Report, error) {
+	var report Report
+	if err := json.NewDecoder(reader).Decode(&report); err != nil {
+		return Report{}, fmt.Errorf("cannot read report: %w", err)
+	}
+	return report, nil
+}
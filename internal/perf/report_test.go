@@ -0,0 +1,47 @@
+package perf
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestReportRoundTrip tests that a report written by WriteReport is read back
+// unchanged by ReadReport.
+func TestReportRoundTrip(t *testing.T) {
+	want := Report{
+		Tool:     "perfcheck",
+		Baseline: "Standard",
+		Loops:    10,
+		Results: []Result{
+			{Algorithm: "Quick", Case: Unsorted, Size: 1000, Micros: 60,
+				Memory: MemUsage{Bytes: 24, Allocs: 1}},
+			{Algorithm: "Bubble", Case: Sorted, Size: 100000, Micros: 4305941, Extrapolated: true},
+		},
+	}
+	name := filepath.Join(t.TempDir(), "report.json")
+	if err := WriteReport(name, want); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	got, err := ReadReport(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v but want %v", got, want)
+	}
+}
+
+// TestReadReportInvalid tests that ReadReport rejects malformed input.
+func TestReadReportInvalid(t *testing.T) {
+	if _, err := ReadReport(strings.NewReader("{")); err == nil {
+		t.Error("got no error but want one")
+	}
+}
@@ -0,0 +1,40 @@
+package perf
+
+// ProcCounts returns the GOMAXPROCS values of a scalability sweep: the powers
+// of two below the specified maximum, followed by the maximum itself.
+func ProcCounts(maxProcs int) []int {
+	var counts []int
+	for procs := 1; procs < maxProcs; procs *= 2 {
+		counts = append(counts, procs)
+	}
+	return append(counts, max(maxProcs, 1))
+}
+
+// Speedup returns how many times faster a run with duration parallel is
+// compared to a run with duration serial. It returns 0 if one of the
+// durations is not positive.
+func Speedup(serial float64, parallel float64) float64 {
+	if serial <= 0 || parallel <= 0 {
+		return 0
+	}
+	return serial / parallel
+}
+
+// Efficiency returns the parallel efficiency of the specified speedup on the
+// specified number of processors, that is the speedup per processor.
+func Efficiency(speedup float64, procs int) float64 {
+	return speedup / float64(procs)
+}
+
+// SerialFraction returns the fraction of the work that cannot be
+// parallelized according to Amdahl's law, estimated from the speedup measured
+// on the specified number of processors (the Karp-Flatt metric). The boolean
+// result is false if the fraction cannot be estimated, which is the case for
+// a single processor or an unknown speedup.
+func SerialFraction(speedup float64, procs int) (float64, bool) {
+	if procs < 2 || speedup <= 0 {
+		return 0, false
+	}
+	p := float64(procs)
+	return (1/speedup - 1/p) / (1 - 1/p), true
+}
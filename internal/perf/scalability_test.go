@@ -0,0 +1,83 @@
+package perf
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestProcCounts tests the ProcCounts function.
+func TestProcCounts(t *testing.T) {
+	tests := map[string]struct {
+		maxProcs int
+		want     []int
+	}{
+		"zero": {
+			maxProcs: 0,
+			want:     []int{1},
+		},
+		"one": {
+			maxProcs: 1,
+			want:     []int{1},
+		},
+		"power_of_two": {
+			maxProcs: 8,
+			want:     []int{1, 2, 4, 8},
+		},
+		"no_power_of_two": {
+			maxProcs: 6,
+			want:     []int{1, 2, 4, 6},
+		},
+	}
+	for name, test := range tests {
+		got := ProcCounts(test.maxProcs)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
+
+// TestSerialFraction tests that SerialFraction inverts Amdahl's law.
+func TestSerialFraction(t *testing.T) {
+	tests := map[string]struct {
+		fraction float64
+		procs    int
+	}{
+		"fully_parallel": {
+			fraction: 0,
+			procs:    4,
+		},
+		"fully_serial": {
+			fraction: 1,
+			procs:    4,
+		},
+		"mostly_parallel": {
+			fraction: 0.1,
+			procs:    16,
+		},
+	}
+	for name, test := range tests {
+		// Amdahl's law: the speedup on p processors for serial fraction f.
+		speedup := 1 / (test.fraction + (1-test.fraction)/float64(test.procs))
+		got, ok := SerialFraction(speedup, test.procs)
+		if !ok || math.Abs(got-test.fraction) > 1e-9 {
+			t.Errorf("%s: got %v, %v but want %v", name, got, ok, test.fraction)
+		}
+		if efficiency := Efficiency(speedup, test.procs); efficiency > 1+1e-9 {
+			t.Errorf("%s: got efficiency %v above 1", name, efficiency)
+		}
+	}
+	if _, ok := SerialFraction(1, 1); ok {
+		t.Errorf("got a serial fraction for a single processor")
+	}
+}
+
+// TestSpeedup tests the Speedup function.
+func TestSpeedup(t *testing.T) {
+	if got := Speedup(100, 25); got != 4 {
+		t.Errorf("got %v but want %v", got, 4)
+	}
+	if got := Speedup(100, 0); got != 0 {
+		t.Errorf("got %v but want %v", got, 0)
+	}
+}
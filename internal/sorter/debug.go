@@ -0,0 +1,6 @@
+//go:build debug
+
+package sorter
+
+// debugBuild enables the checks of VerifyingSorter unconditionally.
+const debugBuild = true
@@ -0,0 +1,152 @@
+package sorter
+
+// PartialStrategy selects the algorithm that PartialSorter uses.
+type PartialStrategy int
+
+const (
+	AutoStrategy        PartialStrategy = iota // chooses by the ratio of k to the length
+	HeapStrategy                               // O(n log k), best for small k
+	QuickselectStrategy                        // O(n + k log k), best for large k
+)
+
+// heapRatio is the minimum ratio of the length to k for which AutoStrategy
+// chooses HeapStrategy. Below it, too many elements enter the heap and
+// quickselect is faster, as BenchmarkPartialSort shows with random data.
+const heapRatio = 100
+
+// PartialSorter sorts only the smallest or selects only the largest elements
+// of a slice. The zero value chooses the strategy automatically.
+type PartialSorter struct {
+	Strategy PartialStrategy
+}
+
+// PartialSort moves the k smallest elements of the specified slice to its
+// start in ascending order. The order of the remaining elements is
+// unspecified. A k that is not in the range from 0 to the length of the slice
+// is clamped to it.
+func PartialSort(slice []int, k int) {
+	PartialSorter{}.PartialSort(slice, k)
+}
+
+// TopK returns a new slice with the k largest elements of the specified
+// slice in descending order. The specified slice is not modified. A k that is
+// not in the range from 0 to the length of the slice is clamped to it.
+func TopK(slice []int, k int) []int {
+	return PartialSorter{}.TopK(slice, k)
+}
+
+// PartialSort moves the k smallest elements of the specified slice to its
+// start in ascending order, see the package function PartialSort.
+func (s PartialSorter) PartialSort(slice []int, k int) {
+	k = clamp(k, len(slice))
+	if s.strategy(len(slice), k) == HeapStrategy {
+		selectWithHeap(slice, k)
+	} else {
+		selectWithQuickselect(slice, k)
+		heapify(slice[:k], greater)
+	}
+	sortHeap(slice[:k], greater)
+}
+
+// TopK returns a new slice with the k largest elements of the specified
+// slice in descending order, see the package function TopK.
+func (s PartialSorter) TopK(slice []int, k int) []int {
+	k = clamp(k, len(slice))
+	var result []int
+	if s.strategy(len(slice), k) == HeapStrategy {
+		// Keeps the k largest elements seen so far in a min-heap, so that
+		// the slice does not need to be copied.
+		result = append(make([]int, 0, k), slice[:k]...)
+		heapify(result, less)
+		for _, element := range slice[k:] {
+			if k > 0 && element > result[0] {
+				result[0] = element
+				siftDown(result, 0, less)
+			}
+		}
+	} else {
+		data := append([]int{}, slice...)
+		selectWithQuickselect(data, len(data)-k)
+		result = data[len(data)-k:]
+		heapify(result, less)
+	}
+	sortHeap(result, less)
+	return result
+}
+
+// strategy returns the strategy to use for selecting k of n elements.
+func (s PartialSorter) strategy(n int, k int) PartialStrategy {
+	if s.Strategy != AutoStrategy {
+		return s.Strategy
+	}
+	if k <= n/heapRatio {
+		return HeapStrategy
+	}
+	return QuickselectStrategy
+}
+
+// clamp returns k limited to the range from 0 to n.
+func clamp(k int, n int) int {
+	return min(max(k, 0), n)
+}
+
+// greater and less are the orders of a max-heap and a min-heap.
+func greater(a, b int) bool { return a > b }
+func less(a, b int) bool    { return a < b }
+
+// selectWithHeap moves the k smallest elements of the specified slice to its
+// start, arranged as a max-heap.
+func selectWithHeap(slice []int, k int) {
+	heap := slice[:k]
+	heapify(heap, greater)
+	for i := k; i < len(slice); i++ {
+		if k > 0 && slice[i] < heap[0] {
+			heap[0], slice[i] = slice[i], heap[0]
+			siftDown(heap, 0, greater)
+		}
+	}
+}
+
+// selectWithQuickselect moves the k smallest elements of the specified slice
+// to its start, in unspecified order.
+func selectWithQuickselect(slice []int, k int) {
+	if 0 < k && k < len(slice) {
+		Select(slice, k-1)
+	}
+}
+
+// heapify arranges the specified slice as a heap in which no element is
+// before its parent in the specified order.
+func heapify(heap []int, before func(a, b int) bool) {
+	for i := len(heap)/2 - 1; i >= 0; i-- {
+		siftDown(heap, i, before)
+	}
+}
+
+// siftDown moves the element at the specified index of the heap down until
+// none of its children is before it.
+func siftDown(heap []int, i int, before func(a, b int) bool) {
+	for {
+		child := 2*i + 1
+		if child >= len(heap) {
+			return
+		}
+		if child+1 < len(heap) && before(heap[child+1], heap[child]) {
+			child++
+		}
+		if !before(heap[child], heap[i]) {
+			return
+		}
+		heap[i], heap[child] = heap[child], heap[i]
+		i = child
+	}
+}
+
+// sortHeap sorts the specified heap so that the root element ends up last. A
+// max-heap is sorted ascending and a min-heap descending.
+func sortHeap(heap []int, before func(a, b int) bool) {
+	for end := len(heap) - 1; end > 0; end-- {
+		heap[0], heap[end] = heap[end], heap[0]
+		siftDown(heap[:end], 0, before)
+	}
+}
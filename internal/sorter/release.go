@@ -0,0 +1,7 @@
+//go:build !debug
+
+package sorter
+
+// debugBuild is false in release builds, so VerifyingSorter only checks when
+// the environment variable VerifyEnv is set.
+const debugBuild = false
@@ -0,0 +1,91 @@
+package sorter
+
+// The search functions expect the slice to be sorted in ascending order. They
+// return indexes at which the specified element could be inserted without
+// breaking the order.
+
+// LowerBound returns the index of the first element of the specified slice
+// that is greater than or equal to the specified element, or the length of
+// the slice if there is none. It uses binary search.
+func LowerBound(slice []int, element int) int {
+	low, high := 0, len(slice)
+	for low < high {
+		middle := int(uint(low+high) >> 1)
+		if slice[middle] < element {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	return low
+}
+
+// UpperBound returns the index of the first element of the specified slice
+// that is greater than the specified element, or the length of the slice if
+// there is none. It uses binary search.
+func UpperBound(slice []int, element int) int {
+	low, high := 0, len(slice)
+	for low < high {
+		middle := int(uint(low+high) >> 1)
+		if slice[middle] <= element {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	return low
+}
+
+// EqualRange returns the range [from, to) of the elements of the specified
+// slice that are equal to the specified element. If there are none, the range
+// is empty and from is the LowerBound.
+func EqualRange(slice []int, element int) (from int, to int) {
+	from = LowerBound(slice, element)
+	return from, from + UpperBound(slice[from:], element)
+}
+
+// ExponentialSearch returns the LowerBound of the specified element and
+// whether the element was found. It probes the slice from the start in steps
+// that double in size and then searches binary between the last two probes.
+// This takes O(log i) time for the resulting index i, so it is faster than
+// binary search for elements near the start of a long slice.
+func ExponentialSearch(slice []int, element int) (int, bool) {
+	i := gallop(slice, 0, element)
+	return i, i < len(slice) && slice[i] == element
+}
+
+// InterpolationSearch returns the LowerBound of the specified element and
+// whether the element was found. It estimates the position of the element
+// from the values at the ends of the searched range, assuming that the values
+// are evenly distributed. It takes O(log log n) time on average for uniformly
+// distributed values, but O(n) time in the worst case, for example if the
+// values grow exponentially.
+func InterpolationSearch(slice []int, element int) (int, bool) {
+	// The result is in the range [low, high].
+	low, high := 0, len(slice)
+	for low < high {
+		first, last := slice[low], slice[high-1]
+		if element <= first {
+			break
+		}
+		if element > last {
+			low = high
+			break
+		}
+		// Now first < element <= last, so the result is in (low, high-1]. If
+		// the element equals the last value, interpolation would only move
+		// high by one, so the range is halved to skip runs of duplicates.
+		probe := low + (high-low)/2
+		if element < last {
+			fraction := (float64(element) - float64(first)) / (float64(last) - float64(first))
+			probe = low + int(fraction*float64(high-1-low))
+			probe = min(max(probe, low+1), high-1)
+		}
+		if slice[probe] < element {
+			low = probe + 1
+		} else {
+			high = probe
+		}
+	}
+	return low, low < len(slice) && slice[low] == element
+}
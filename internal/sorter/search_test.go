@@ -0,0 +1,148 @@
+package sorter
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// TestSearch tests all search functions with small sorted slices.
+func TestSearch(t *testing.T) {
+	tests := map[string]struct {
+		slice    []int
+		element  int
+		from, to int
+	}{
+		"empty_input":    {[]int{}, 1, 0, 0},
+		"before_all":     {[]int{2, 4, 6}, 1, 0, 0},
+		"after_all":      {[]int{2, 4, 6}, 7, 3, 3},
+		"first":          {[]int{2, 4, 6}, 2, 0, 1},
+		"last":           {[]int{2, 4, 6}, 6, 2, 3},
+		"between":        {[]int{2, 4, 6}, 5, 2, 2},
+		"duplicates":     {[]int{1, 3, 3, 3, 5}, 3, 1, 4},
+		"all_equal":      {[]int{7, 7, 7, 7}, 7, 0, 4},
+		"negative":       {[]int{-9, -5, 0, 5}, -5, 1, 2},
+		"extreme_values": {[]int{math.MinInt, -1, 0, math.MaxInt}, math.MaxInt - 1, 3, 3},
+	}
+	for name, test := range tests {
+		if got := LowerBound(test.slice, test.element); got != test.from {
+			t.Errorf("%s: lower bound got %d but want %d", name, got, test.from)
+		}
+		if got := UpperBound(test.slice, test.element); got != test.to {
+			t.Errorf("%s: upper bound got %d but want %d", name, got, test.to)
+		}
+		if from, to := EqualRange(test.slice, test.element); from != test.from || to != test.to {
+			t.Errorf("%s: equal range got [%d, %d) but want [%d, %d)", name, from, to, test.from, test.to)
+		}
+		found := test.from < test.to
+		if got, ok := ExponentialSearch(test.slice, test.element); got != test.from || ok != found {
+			t.Errorf("%s: exponential search got %d, %v but want %d, %v", name, got, ok, test.from, found)
+		}
+		if got, ok := InterpolationSearch(test.slice, test.element); got != test.from || ok != found {
+			t.Errorf("%s: interpolation search got %d, %v but want %d, %v", name, got, ok, test.from, found)
+		}
+	}
+}
+
+// TestLargeSearch tests all search functions with large slices of all
+// searchArrangements against sort.SearchInts.
+func TestLargeSearch(t *testing.T) {
+	for _, distribution := range searchArrangements {
+		name := distribution.Name
+		slice := CreateRandomInts(10000)
+		distribution.Arrange(slice)
+		slice[len(slice)/2] = slice[len(slice)/2+1] // at least one duplicate
+		sort.Ints(slice)
+		elements := []int{math.MinInt, math.MaxInt, slice[0], slice[len(slice)-1]}
+		for i := 0; i < 1000; i++ {
+			elements = append(elements, slice[rand.Intn(len(slice))], slice[rand.Intn(len(slice))]+1)
+		}
+		for _, element := range elements {
+			from := sort.SearchInts(slice, element)
+			to := sort.SearchInts(slice, element+1)
+			if element == math.MaxInt {
+				to = len(slice)
+			}
+			found := from < to
+			if got, ok := ExponentialSearch(slice, element); got != from || ok != found {
+				t.Errorf("%s: exponential search for %d got %d, %v but want %d, %v", name, element, got, ok, from, found)
+			}
+			if got, ok := InterpolationSearch(slice, element); got != from || ok != found {
+				t.Errorf("%s: interpolation search for %d got %d, %v but want %d, %v", name, element, got, ok, from, found)
+			}
+			if gotFrom, gotTo := EqualRange(slice, element); gotFrom != from || gotTo != to {
+				t.Errorf("%s: equal range for %d got [%d, %d) but want [%d, %d)", name, element, gotFrom, gotTo, from, to)
+			}
+		}
+	}
+}
+
+// searchArrangements are all distributions plus skewed values, on which
+// interpolation search degrades, and few different values, which give long
+// runs of equal values.
+var searchArrangements = append(append([]Distribution{}, Distributions...),
+	Distribution{"skewed", func(slice []int) {
+		copy(slice, CreateSkewedInts(len(slice)))
+	}},
+	Distribution{"few_values", func(slice []int) {
+		for i := range slice {
+			slice[i] %= 10
+		}
+	}},
+)
+
+// FuzzInterpolationSearch checks the InterpolationSearch function with fuzzed
+// input against LowerBound. The input bytes are taken as signed values and
+// scaled, so that there are big gaps between the values.
+func FuzzInterpolationSearch(f *testing.F) {
+	f.Add([]byte{1, 2, 3}, int8(2), uint8(0))
+	f.Add([]byte{0x80, 0, 0, 0x7f}, int8(0), uint8(62))
+	f.Fuzz(func(t *testing.T, input []byte, element int8, shift uint8) {
+		shift %= 64
+		slice := make([]int, len(input))
+		for i, b := range input {
+			slice[i] = int(int8(b)) << shift
+		}
+		sort.Ints(slice)
+		target := int(element) << shift
+		want := LowerBound(slice, target)
+		found := want < len(slice) && slice[want] == target
+		if got, ok := InterpolationSearch(slice, target); got != want || ok != found {
+			t.Fatalf("got %d, %v for %d in %v but want %d, %v", got, ok, target, slice, want, found)
+		}
+	})
+}
+
+// BenchmarkSearch benchmarks all search functions and sort.SearchInts with
+// all searchArrangements. The elements searched for are
+// taken from the data, so that they are distributed like it.
+func BenchmarkSearch(b *testing.B) {
+	searchFunctions := map[string]func([]int, int) int{
+		"LowerBound":          LowerBound,
+		"SearchInts":          sort.SearchInts,
+		"ExponentialSearch":   func(slice []int, element int) int { i, _ := ExponentialSearch(slice, element); return i },
+		"InterpolationSearch": func(slice []int, element int) int { i, _ := InterpolationSearch(slice, element); return i },
+	}
+	for _, size := range []int{1000, 1000000} {
+		for _, distribution := range searchArrangements {
+			dataName := distribution.Name
+			slice := CreateRandomInts(size)
+			distribution.Arrange(slice)
+			sort.Ints(slice)
+			elements := make([]int, 1024)
+			for i := range elements {
+				elements[i] = slice[rand.Intn(len(slice))]
+			}
+			for name, searchFunction := range searchFunctions {
+				benchmarkName := fmt.Sprintf("algorithm=%s/data=%s/n=%d", name, dataName, size)
+				b.Run(benchmarkName, func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						searchFunction(slice, elements[i%len(elements)])
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package sorter
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Select rearranges the specified slice so that the element at index k is
+// the one that would be there if the slice were sorted. All elements before
+// it are less than or equal to it and all elements after it are greater than
+// or equal to it. It uses the same partitioning as QuickSort and excludes the
+// elements equal to the pivot together with it in every round. If a round
+// does not shrink the range to three quarters, the next round uses a
+// median-of-medians pivot, which shrinks it to at most 70 percent. So it runs
+// in linear time even in the worst case, also with many equal elements.
+// Select panics if k is out of range.
+func Select(slice []int, k int) {
+	if k < 0 || k >= len(slice) {
+		panic(fmt.Sprintf("sorter: index %d out of range [0:%d]", k, len(slice)))
+	}
+	from, to := 0, len(slice)
+	useMedianOfMedians := false
+	for to-from > 1 {
+		part := slice[from:to]
+		if useMedianOfMedians {
+			pivotIndex := MedianOfMedians(part)
+			part[0], part[pivotIndex] = part[pivotIndex], part[0]
+		} else {
+			selectBestPivot(part)
+		}
+		pivotIndex := splitUsingPivot(part)
+
+		// Elements equal to the pivot all end up before it. They are moved
+		// next to it, so that they are excluded together with it and cannot
+		// keep the range from shrinking.
+		equalIndex := gatherEqual(part, pivotIndex)
+
+		switch {
+		case k < from+equalIndex:
+			to = from + equalIndex
+		case k > from+pivotIndex:
+			from += pivotIndex + 1
+		default:
+			return
+		}
+		useMedianOfMedians = 4*(to-from) > 3*len(part)
+	}
+}
+
+// gatherEqual moves the elements before the pivot at the specified index that
+// are equal to it right in front of it. It returns the index of the first
+// element that is equal to the pivot.
+func gatherEqual(slice []int, pivotIndex int) int {
+	equalIndex := pivotIndex
+	for i := pivotIndex - 1; i >= 0; i-- {
+		if slice[i] == slice[pivotIndex] {
+			equalIndex--
+			slice[i], slice[equalIndex] = slice[equalIndex], slice[i]
+		}
+	}
+	return equalIndex
+}
+
+// MedianOfMedians returns the index of an approximate median of the specified
+// slice, which is greater than or equal to at least 30 percent of the
+// elements and less than or equal to at least 30 percent of them. It sorts
+// groups of five elements, moves their medians to the start of the slice and
+// selects the median of those. The slice must not be empty.
+func MedianOfMedians(slice []int) int {
+	if len(slice) < 5 {
+		insertionSort(slice)
+		return (len(slice) - 1) / 2
+	}
+	groups := 0
+	for i := 0; i+5 <= len(slice); i += 5 {
+		group := slice[i : i+5]
+		insertionSort(group)
+		slice[groups], group[2] = group[2], slice[groups]
+		groups++
+	}
+	Select(slice[:groups], (groups-1)/2)
+	return (groups - 1) / 2
+}
+
+// insertionSort sorts the specified short slice using the insertion sort
+// algorithm.
+func insertionSort(slice []int) {
+	for i := 1; i < len(slice); i++ {
+		for j := i; j > 0 && slice[j] < slice[j-1]; j-- {
+			slice[j], slice[j-1] = slice[j-1], slice[j]
+		}
+	}
+}
+
+// Median returns the lower median of the specified slice and rearranges it
+// like Select. It panics if the slice is empty.
+func Median(slice []int) int {
+	k := (len(slice) - 1) / 2
+	Select(slice, k)
+	return slice[k]
+}
+
+// Quantiles returns the specified quantiles of the specified slice, each
+// between 0 and 1, in the order of the arguments. The q-quantile is the
+// element at the nearest rank ceil(q*n) in the sorted slice, or the smallest
+// element for q = 0. The slice is rearranged like Select for every quantile.
+// Quantiles panics if the slice is empty or a quantile is out of range.
+func Quantiles(slice []int, qs ...float64) []int {
+	indexes := quantileIndexes(len(slice), qs)
+
+	// Selecting the indexes in ascending order leaves every range between
+	// two of them for the next selection.
+	order := make([]int, len(qs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return indexes[order[i]] < indexes[order[j]] })
+	result := make([]int, len(qs))
+	from := 0
+	for _, i := range order {
+		if indexes[i] >= from {
+			Select(slice[from:], indexes[i]-from)
+			from = indexes[i] + 1
+		}
+		result[i] = slice[indexes[i]]
+	}
+	return result
+}
+
+// quantileIndexes returns the indexes of the specified quantiles in a sorted
+// slice of the specified length.
+func quantileIndexes(length int, qs []float64) []int {
+	if length == 0 {
+		panic("sorter: quantiles of empty slice")
+	}
+	indexes := make([]int, len(qs))
+	for i, q := range qs {
+		if !(0 <= q && q <= 1) {
+			panic(fmt.Sprintf("sorter: quantile %v out of range [0, 1]", q))
+		}
+		indexes[i] = max(int(math.Ceil(q*float64(length)))-1, 0)
+	}
+	return indexes
+}
@@ -0,0 +1,165 @@
+package sorter
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// selectArrangements are all distributions plus inputs with many equal
+// elements, which are the hard cases for selection.
+var selectArrangements = append(append([]Distribution{}, Distributions...),
+	Distribution{"few_values", func(slice []int) {
+		for i := range slice {
+			slice[i] %= 3
+		}
+	}},
+	Distribution{"all_equal", func(slice []int) {
+		for i := range slice {
+			slice[i] = 7
+		}
+	}},
+)
+
+// TestSelect tests that after Select all elements before index k are less
+// than or equal to the element at k and all elements after it are greater
+// than or equal to it.
+func TestSelect(t *testing.T) {
+	for _, distribution := range selectArrangements {
+		for _, size := range []int{1, 2, 5, 13, 1000, 100000} {
+			original := CreateRandomInts(size)
+			distribution.Arrange(original)
+			want := append([]int{}, original...)
+			sort.Ints(want)
+			for _, k := range []int{0, size / 3, size / 2, size - 1, rand.Intn(size)} {
+				name := fmt.Sprintf("%s/n=%d/k=%d", distribution.Name, size, k)
+				slice := append([]int{}, original...)
+				Select(slice, k)
+				if slice[k] != want[k] {
+					t.Errorf("%s: got %v but want %v", name, slice[k], want[k])
+				}
+				for i, element := range slice {
+					if i < k && element > slice[k] || i > k && element < slice[k] {
+						t.Errorf("%s: element %v at %d is on the wrong side", name, element, i)
+						break
+					}
+				}
+				if !IsPermutation(slice, original) {
+					t.Errorf("%s: got no permutation of the input", name)
+				}
+			}
+		}
+	}
+}
+
+// TestSelectOutOfRange tests that Select panics for invalid indexes.
+func TestSelectOutOfRange(t *testing.T) {
+	for _, k := range []int{-1, 3} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("k=%d: got no panic", k)
+				}
+			}()
+			Select([]int{1, 2, 3}, k)
+		}()
+	}
+}
+
+// TestMedianOfMedians tests that the approximate median is greater than or
+// equal to and less than or equal to at least 30 percent of the elements.
+func TestMedianOfMedians(t *testing.T) {
+	for _, distribution := range selectArrangements {
+		for _, size := range []int{1, 4, 5, 99, 10000} {
+			slice := CreateRandomInts(size)
+			distribution.Arrange(slice)
+			median := slice[MedianOfMedians(slice)]
+			lessOrEqual, greaterOrEqual := 0, 0
+			for _, element := range slice {
+				if element <= median {
+					lessOrEqual++
+				}
+				if element >= median {
+					greaterOrEqual++
+				}
+			}
+			if size >= 5 && (10*lessOrEqual < 3*size || 10*greaterOrEqual < 3*size) {
+				t.Errorf("%s/n=%d: median %v is not central", distribution.Name, size, median)
+			}
+		}
+	}
+}
+
+// TestMedianAndQuantiles tests the order statistics against a sorted copy.
+func TestMedianAndQuantiles(t *testing.T) {
+	tests := map[string]struct {
+		slice      []int
+		qs         []float64
+		wantMedian int
+		want       []int
+	}{
+		"one_element": {
+			slice:      []int{42},
+			qs:         []float64{0, 0.5, 1},
+			wantMedian: 42,
+			want:       []int{42, 42, 42},
+		},
+		"even_length": {
+			slice:      []int{4, 1, 3, 2},
+			qs:         []float64{0.5, 0.25, 0.75, 1},
+			wantMedian: 2,
+			want:       []int{2, 1, 3, 4},
+		},
+		"deciles": {
+			slice:      []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+			qs:         []float64{0.1, 0.2, 0.9, 0.3, 0.95},
+			wantMedian: 5,
+			want:       []int{1, 2, 9, 3, 10},
+		},
+		"duplicate_quantiles": {
+			slice:      []int{5, -5, 0, 5, -5},
+			qs:         []float64{0.5, 0.5, 0},
+			wantMedian: 0,
+			want:       []int{0, 0, -5},
+		},
+		"no_quantiles": {
+			slice:      []int{3, 1, 2},
+			qs:         nil,
+			wantMedian: 2,
+			want:       []int{},
+		},
+	}
+	for name, test := range tests {
+		slice := append([]int{}, test.slice...)
+		if got := Median(slice); got != test.wantMedian {
+			t.Errorf("%s: median got %v but want %v", name, got, test.wantMedian)
+		}
+		if got := Quantiles(slice, test.qs...); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
+
+// TestQuantilesOutOfRange tests that Quantiles panics for invalid input.
+func TestQuantilesOutOfRange(t *testing.T) {
+	tests := map[string]struct {
+		slice []int
+		qs    []float64
+	}{
+		"empty_slice": {[]int{}, []float64{0.5}},
+		"negative":    {[]int{1}, []float64{-0.1}},
+		"above_one":   {[]int{1}, []float64{1.1}},
+	}
+	for name, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: got no panic", name)
+				}
+			}()
+			Quantiles(test.slice, test.qs...)
+		}()
+	}
+}
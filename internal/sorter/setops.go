@@ -0,0 +1,176 @@
+package sorter
+
+// The set operations treat sorted slices as sets: the slices may contain
+// duplicates, but the results contain every element only once.
+
+// gallopRatio is the minimum ratio of the lengths of two slices from which on
+// the elements of the shorter slice are searched in the longer one by
+// galloping, instead of walking through both slices.
+const gallopRatio = 16
+
+// Unique removes the duplicates from the specified sorted slice in place and
+// returns the shortened slice.
+func Unique(slice []int) []int {
+	if len(slice) == 0 {
+		return slice
+	}
+	length := 1
+	for i := 1; i < len(slice); i++ {
+		if slice[i] != slice[length-1] {
+			slice[length] = slice[i]
+			length++
+		}
+	}
+	return slice[:length]
+}
+
+// Union returns a new sorted slice with the elements that are in a or b.
+func Union(a []int, b []int) []int {
+	result := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		var next int
+		switch {
+		case j == len(b) || i < len(a) && a[i] < b[j]:
+			next = a[i]
+			i++
+		case i == len(a) || b[j] < a[i]:
+			next = b[j]
+			j++
+		default:
+			next = a[i]
+			i++
+			j++
+		}
+		result = appendUnique(result, next)
+	}
+	return result
+}
+
+// Intersect returns a new sorted slice with the elements that are in both a
+// and b.
+func Intersect(a []int, b []int) []int {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	result := []int{}
+	search := searchFunction(a, b)
+	j := 0
+	for _, element := range a {
+		if j = search(b, j, element); j == len(b) {
+			break
+		}
+		if b[j] == element {
+			result = appendUnique(result, element)
+		}
+	}
+	return result
+}
+
+// Difference returns a new sorted slice with the elements of a that are not
+// in b.
+func Difference(a []int, b []int) []int {
+	result := []int{}
+	search := searchFunction(a, b)
+	j := 0
+	for _, element := range a {
+		j = search(b, j, element)
+		if j == len(b) || b[j] != element {
+			result = appendUnique(result, element)
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns a new sorted slice with the elements that are
+// in either a or b, but not in both.
+func SymmetricDifference(a []int, b []int) []int {
+	result := []int{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || i < len(a) && a[i] < b[j]:
+			result = appendUnique(result, a[i])
+			i++
+		case i == len(a) || b[j] < a[i]:
+			result = appendUnique(result, b[j])
+			j++
+		default:
+			// Skips all duplicates of the common element.
+			common := a[i]
+			for i < len(a) && a[i] == common {
+				i++
+			}
+			for j < len(b) && b[j] == common {
+				j++
+			}
+		}
+	}
+	return result
+}
+
+// IsSubset reports whether every element of a is also in b.
+func IsSubset(a []int, b []int) bool {
+	search := searchFunction(a, b)
+	j := 0
+	for _, element := range a {
+		j = search(b, j, element)
+		if j == len(b) || b[j] != element {
+			return false
+		}
+	}
+	return true
+}
+
+// appendUnique appends the specified element to the sorted slice unless it
+// is already the last element.
+func appendUnique(slice []int, element int) []int {
+	if len(slice) > 0 && slice[len(slice)-1] == element {
+		return slice
+	}
+	return append(slice, element)
+}
+
+// searchFunction returns the function that finds the elements of a in b. If b
+// is much longer than a, galloping is faster than walking through b.
+func searchFunction(a []int, b []int) func(slice []int, from int, element int) int {
+	if len(b) >= gallopRatio*len(a) {
+		return gallop
+	}
+	return walk
+}
+
+// walk returns the index of the first element from the specified index on
+// that is greater than or equal to the specified element, or the length of
+// the slice if there is none. It walks through the slice one by one.
+func walk(slice []int, from int, element int) int {
+	for from < len(slice) && slice[from] < element {
+		from++
+	}
+	return from
+}
+
+// gallop returns the same index as walk, but it probes the slice in steps
+// that double in size and then searches binary between the last two probes.
+// This takes O(log d) time for the distance d to the result.
+func gallop(slice []int, from int, element int) int {
+	low, step := from, 1
+	for low+step < len(slice) && slice[low+step] < element {
+		low += step
+		step *= 2
+	}
+	if low < len(slice) && slice[low] >= element {
+		return low
+	}
+	// The result is in the range (low, high].
+	high := min(low+step, len(slice))
+	for low+1 < high {
+		middle := low + (high-low)/2
+		if slice[middle] < element {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+	return high
+}
@@ -0,0 +1,211 @@
+package sorter
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// TestSetOperations tests all set operations with small sorted slices.
+func TestSetOperations(t *testing.T) {
+	tests := map[string]struct {
+		a, b                  []int
+		union, intersect      []int
+		difference, symmetric []int
+		subset                bool
+	}{
+		"both_empty": {
+			a: []int{}, b: []int{},
+			union: []int{}, intersect: []int{},
+			difference: []int{}, symmetric: []int{},
+			subset: true,
+		},
+		"a_empty": {
+			a: []int{}, b: []int{1, 2},
+			union: []int{1, 2}, intersect: []int{},
+			difference: []int{}, symmetric: []int{1, 2},
+			subset: true,
+		},
+		"b_empty": {
+			a: []int{1, 2}, b: []int{},
+			union: []int{1, 2}, intersect: []int{},
+			difference: []int{1, 2}, symmetric: []int{1, 2},
+			subset: false,
+		},
+		"overlapping": {
+			a: []int{-3, 1, 2, 5, 8}, b: []int{1, 3, 5, 7},
+			union: []int{-3, 1, 2, 3, 5, 7, 8}, intersect: []int{1, 5},
+			difference: []int{-3, 2, 8}, symmetric: []int{-3, 2, 3, 7, 8},
+			subset: false,
+		},
+		"subset": {
+			a: []int{2, 4}, b: []int{1, 2, 3, 4},
+			union: []int{1, 2, 3, 4}, intersect: []int{2, 4},
+			difference: []int{}, symmetric: []int{1, 3},
+			subset: true,
+		},
+		"duplicates": {
+			a: []int{1, 1, 2, 2, 2, 4}, b: []int{1, 2, 2, 3, 3},
+			union: []int{1, 2, 3, 4}, intersect: []int{1, 2},
+			difference: []int{4}, symmetric: []int{3, 4},
+			subset: false,
+		},
+		"equal": {
+			a: []int{1, 2, 3}, b: []int{1, 2, 3},
+			union: []int{1, 2, 3}, intersect: []int{1, 2, 3},
+			difference: []int{}, symmetric: []int{},
+			subset: true,
+		},
+	}
+	for name, test := range tests {
+		if got := Union(test.a, test.b); !reflect.DeepEqual(got, test.union) {
+			t.Errorf("%s: union got %v but want %v", name, got, test.union)
+		}
+		if got := Intersect(test.a, test.b); !reflect.DeepEqual(got, test.intersect) {
+			t.Errorf("%s: intersect got %v but want %v", name, got, test.intersect)
+		}
+		if got := Difference(test.a, test.b); !reflect.DeepEqual(got, test.difference) {
+			t.Errorf("%s: difference got %v but want %v", name, got, test.difference)
+		}
+		if got := SymmetricDifference(test.a, test.b); !reflect.DeepEqual(got, test.symmetric) {
+			t.Errorf("%s: symmetric difference got %v but want %v", name, got, test.symmetric)
+		}
+		if got := IsSubset(test.a, test.b); got != test.subset {
+			t.Errorf("%s: subset got %v but want %v", name, got, test.subset)
+		}
+	}
+}
+
+// TestUnique tests the in-place removal of duplicates.
+func TestUnique(t *testing.T) {
+	tests := map[string]struct {
+		slice []int
+		want  []int
+	}{
+		"empty_input":   {[]int{}, []int{}},
+		"one_element":   {[]int{42}, []int{42}},
+		"no_duplicates": {[]int{1, 2, 3}, []int{1, 2, 3}},
+		"duplicates":    {[]int{-1, -1, 0, 3, 3, 3, 7}, []int{-1, 0, 3, 7}},
+		"all_equal":     {[]int{5, 5, 5, 5}, []int{5}},
+	}
+	for name, test := range tests {
+		if got := Unique(test.slice); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
+
+// TestGallop tests the set operations on slices of very unequal lengths,
+// which use galloping, against the walking versions.
+func TestGallop(t *testing.T) {
+	long := CreateRandomInts(10000)
+	for i := range long {
+		long[i] %= 20000
+	}
+	sort.Ints(long)
+	short := append([]int{}, long[100], long[5000], long[9999], 1, 19999, 20001)
+	sort.Ints(short)
+	for _, pair := range [][2][]int{{short, long}, {long, short}} {
+		a, b := pair[0], pair[1]
+		if got, want := Intersect(a, b), setReference(a, b, func(inA, inB bool) bool { return inA && inB }); !reflect.DeepEqual(got, want) {
+			t.Errorf("intersect: got %v but want %v", got, want)
+		}
+		if got, want := Difference(a, b), setReference(a, b, func(inA, inB bool) bool { return inA && !inB }); !reflect.DeepEqual(got, want) {
+			t.Errorf("difference: got %v but want %v", got, want)
+		}
+	}
+	if !IsSubset([]int{long[100], long[5000], long[9999]}, long) || IsSubset(short, long) {
+		t.Errorf("got wrong subset results")
+	}
+	for _, element := range []int{-1, 0, long[0], long[5000], long[9999], 20000} {
+		for _, from := range []int{0, 1, 5000, 10000} {
+			if got, want := gallop(long, from, element), walk(long, from, element); got != want {
+				t.Errorf("gallop(%d, %d): got %v but want %v", from, element, got, want)
+			}
+		}
+	}
+}
+
+// setReference returns the sorted elements of a and b for which the
+// specified function of their membership in a and b is true, computed with
+// maps.
+func setReference(a []int, b []int, include func(inA, inB bool) bool) []int {
+	inA, inB := make(map[int]bool), make(map[int]bool)
+	for _, element := range a {
+		inA[element] = true
+	}
+	for _, element := range b {
+		inB[element] = true
+	}
+	result := []int{}
+	for _, set := range []map[int]bool{inA, inB} {
+		for element := range set {
+			if include(inA[element], inB[element]) && !slices.Contains(result, element) {
+				result = append(result, element)
+			}
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+// FuzzSetOperations checks the set operations on fuzzed sorted slices
+// against map-based reference implementations. Every input byte is taken as
+// a signed value, and the second slice is repeated to make it much longer
+// than the first one in some cases, so that galloping is used.
+func FuzzSetOperations(f *testing.F) {
+	f.Add([]byte{1, 2, 3}, []byte{2, 3, 4}, uint8(1))
+	f.Add([]byte{}, []byte{5, 5, 5}, uint8(0))
+	f.Add([]byte{0x80, 0, 0x7f}, []byte{0x7f, 0x80}, uint8(40))
+	f.Fuzz(func(t *testing.T, aBytes []byte, bBytes []byte, repeat uint8) {
+		a := make([]int, len(aBytes))
+		for i, b := range aBytes {
+			a[i] = int(int8(b))
+		}
+		var b []int
+		for r := 0; r <= int(repeat); r++ {
+			for _, element := range bBytes {
+				b = append(b, int(int8(element)))
+			}
+		}
+		sort.Ints(a)
+		sort.Ints(b)
+
+		check := func(name string, got []int, include func(inA, inB bool) bool) {
+			if want := setReference(a, b, include); !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s of %v and %v: got %v but want %v", name, a, b, got, want)
+			}
+		}
+		check("union", Union(a, b), func(inA, inB bool) bool { return inA || inB })
+		check("intersect", Intersect(a, b), func(inA, inB bool) bool { return inA && inB })
+		check("difference", Difference(a, b), func(inA, inB bool) bool { return inA && !inB })
+		check("symmetric difference", SymmetricDifference(a, b), func(inA, inB bool) bool { return inA != inB })
+		if got, want := IsSubset(a, b), len(Difference(a, b)) == 0; got != want {
+			t.Fatalf("subset of %v and %v: got %v but want %v", a, b, got, want)
+		}
+		check("unique", Unique(slices.Clone(a)), func(inA, inB bool) bool { return inA })
+	})
+}
+
+// BenchmarkIntersect compares galloping with walking for slices of very
+// unequal lengths.
+func BenchmarkIntersect(b *testing.B) {
+	long := CreateRandomInts(1000000)
+	sort.Ints(long)
+	short := CreateRandomInts(100)
+	sort.Ints(short)
+	b.Run("method=gallop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Intersect(short, long)
+		}
+	})
+	b.Run("method=walk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			j := 0
+			for _, element := range short {
+				j = walk(long, j, element)
+			}
+		}
+	})
+}
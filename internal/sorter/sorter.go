@@ -16,6 +16,48 @@ func CreateRandomInts(size int) []int {
 	return result
 }
 
+// CreateSkewedInts returns a slice of the specified size that consists of
+// random non-negative int values whose magnitudes are evenly distributed, so
+// that small values are much more frequent than large ones.
+func CreateSkewedInts(size int) []int {
+	result := make([]int, size)
+	for i := 0; i < size; i++ {
+		result[i] = int(rand.Int63() >> rand.Intn(63))
+	}
+	return result
+}
+
+// Distribution is a named input pattern for sort functions.
+type Distribution struct {
+	Name string
+
+	// Arrange rearranges the specified random slice so that it follows the
+	// input pattern.
+	Arrange func([]int)
+}
+
+// Distributions is a slice of all input patterns that sort functions are
+// benchmarked with.
+var Distributions = []Distribution{
+	{"random", func(slice []int) {}},
+	{"sorted", sort.Ints},
+	{"reversed", func(slice []int) { sort.Sort(sort.Reverse(sort.IntSlice(slice))) }},
+	{"nearly_sorted", arrangeNearlySorted},
+}
+
+// arrangeNearlySorted sorts the specified slice and then swaps one percent of
+// the elements with random other elements.
+func arrangeNearlySorted(slice []int) {
+	sort.Ints(slice)
+	if len(slice) < 2 {
+		return
+	}
+	for i := 0; i <= len(slice)/100; i++ {
+		a, b := rand.Intn(len(slice)), rand.Intn(len(slice))
+		slice[a], slice[b] = slice[b], slice[a]
+	}
+}
+
 // SortFunctions is a slice of all sort functions implemented in this package.
 var SortFunctions = []func([]int){
 	BubbleSort,
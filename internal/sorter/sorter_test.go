@@ -1,8 +1,12 @@
 package sorter
 
 import (
+	"fmt"
 	"reflect"
+	"runtime"
+	"slices"
 	"sort"
+	"strings"
 	"testing"
 )
 
@@ -62,3 +66,122 @@ func TestLargeSlice(t *testing.T) {
 		}
 	}
 }
+
+// TestDistributions tests that all distributions only rearrange the data.
+func TestDistributions(t *testing.T) {
+	for _, distribution := range Distributions {
+		slice := CreateRandomInts(1000)
+		want := make([]int, len(slice))
+		copy(want, slice)
+		sort.Ints(want)
+		distribution.Arrange(slice)
+		if distribution.Name == "sorted" && !sort.IntsAreSorted(slice) {
+			t.Errorf("%s: got unsorted data", distribution.Name)
+		}
+		sort.Ints(slice)
+		if !reflect.DeepEqual(slice, want) {
+			t.Errorf("%s: got other elements than the input", distribution.Name)
+		}
+	}
+}
+
+// benchmarkSizes are the numbers of elements that the sort functions are
+// benchmarked with.
+var benchmarkSizes = []int{100, 1000, 10000}
+
+// BenchmarkSort benchmarks all sort functions with all distributions and
+// sizes. The sub-benchmark names use the key=value form that benchstat can
+// group by, for example:
+//
+//	go test -run=^$ -bench=Sort -count=10 ./internal/sorter > new.txt
+//	benchstat -col /algorithm new.txt
+func BenchmarkSort(b *testing.B) {
+	for _, sortFunction := range SortFunctions {
+		name := functionName(sortFunction)
+		for _, distribution := range Distributions {
+			for _, size := range benchmarkSizes {
+
+				// Bubble sort is too slow on large lists.
+				if name == "BubbleSort" && size > 1000 {
+					continue
+				}
+
+				benchmarkName := fmt.Sprintf("algorithm=%s/dist=%s/n=%d", name, distribution.Name, size)
+				b.Run(benchmarkName, func(b *testing.B) {
+					original := CreateRandomInts(size)
+					distribution.Arrange(original)
+					data := make([]int, size)
+					b.ReportAllocs()
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						// Copying is linear and cheap compared to sorting, so
+						// it is measured rather than paying for StopTimer.
+						copy(data, original)
+						sortFunction(data)
+					}
+					b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/float64(size), "ns/element")
+				})
+			}
+		}
+	}
+}
+
+// functionName returns the name of the specified function without the path
+// and name of this package.
+func functionName(function any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(function).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return strings.TrimPrefix(name, "sorter.")
+}
+
+// FuzzBubbleSort checks the BubbleSort function with fuzzed input.
+func FuzzBubbleSort(f *testing.F) {
+	fuzzSort(f, BubbleSort)
+}
+
+// FuzzQuickSort checks the QuickSort function with fuzzed input.
+func FuzzQuickSort(f *testing.F) {
+	fuzzSort(f, QuickSort)
+}
+
+// FuzzGoroutineSort checks the GoroutineSort function with fuzzed input.
+func FuzzGoroutineSort(f *testing.F) {
+	fuzzSort(f, GoroutineSort)
+}
+
+// fuzzSort checks that the specified sort function returns a sorted
+// permutation of the fuzzed input that is identical to the result of
+// slices.Sort. Every input byte is taken as a signed value, so that negative
+// numbers and duplicates are frequent.
+func fuzzSort(f *testing.F, sortFunction func([]int)) {
+	f.Fuzz(func(t *testing.T, input []byte) {
+		slice := make([]int, len(input))
+		for i, b := range input {
+			slice[i] = int(int8(b))
+		}
+		original := slices.Clone(slice)
+		sortFunction(slice)
+
+		if !sort.IntsAreSorted(slice) {
+			t.Fatalf("got unsorted %v for %v", slice, original)
+		}
+		counts := make(map[int]int)
+		for _, element := range original {
+			counts[element]++
+		}
+		for _, element := range slice {
+			counts[element]--
+		}
+		for element, count := range counts {
+			if count != 0 {
+				t.Fatalf("got %v for %v which is no permutation: %d occurs %d times too often",
+					slice, original, element, -count)
+			}
+		}
+		want := slices.Clone(original)
+		slices.Sort(want)
+		if !slices.Equal(slice, want) {
+			t.Fatalf("got %v but want %v", slice, want)
+		}
+	})
+}
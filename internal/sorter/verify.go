@@ -0,0 +1,111 @@
+package sorter
+
+import (
+	"fmt"
+	"os"
+)
+
+// VerifyEnv is the name of the environment variable that enables the checks
+// of VerifyingSorter in builds without the debug tag. Any non-empty value
+// enables them.
+const VerifyEnv = "SORTER_VERIFY"
+
+// VerificationError describes a sort result that failed verification.
+type VerificationError struct {
+	Index  int    // the first violating index or -1 if there is none
+	Reason string // a description of the violation
+}
+
+func (e *VerificationError) Error() string {
+	if e.Index < 0 {
+		return "sort verification failed: " + e.Reason
+	}
+	return fmt.Sprintf("sort verification failed at index %d: %s", e.Index, e.Reason)
+}
+
+// VerifyingSorter wraps a sort function and checks that its output is sorted
+// and a permutation of its input. The checks are done in builds with the
+// debug tag or if the environment variable VerifyEnv is set.
+type VerifyingSorter struct {
+	SortFunction func([]int)
+}
+
+// Sort sorts the specified slice using the wrapped sort function. If the
+// checks are enabled and fail, a *VerificationError is returned.
+func (v VerifyingSorter) Sort(slice []int) error {
+	if !debugBuild && os.Getenv(VerifyEnv) == "" {
+		v.SortFunction(slice)
+		return nil
+	}
+	hash := multisetHash(slice)
+	v.SortFunction(slice)
+	if i := unsortedIndex(slice); i >= 0 {
+		return &VerificationError{Index: i, Reason: fmt.Sprintf(
+			"element %d is smaller than its predecessor %d", slice[i], slice[i-1])}
+	}
+	if multisetHash(slice) != hash {
+		return &VerificationError{Index: -1, Reason: "output is no permutation of the input"}
+	}
+	return nil
+}
+
+// IsSorted reports whether the specified slice is sorted in ascending order.
+func IsSorted(slice []int) bool {
+	return unsortedIndex(slice) < 0
+}
+
+// IsStablySorted reports whether the specified slice is sorted in ascending
+// order and equal elements are in the order of their original positions.
+// The original position of slice[i] is indices[i].
+func IsStablySorted(slice []int, indices []int) bool {
+	if len(slice) != len(indices) {
+		return false
+	}
+	for i := 1; i < len(slice); i++ {
+		if slice[i] < slice[i-1] || slice[i] == slice[i-1] && indices[i] < indices[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPermutation reports whether the specified slices contain the same
+// elements with the same multiplicities. It compares hashes of the two
+// multisets, so it needs no extra memory and runs in linear time. Each hash
+// is the sum of two independent mixes of the elements, so different multisets
+// are reported as permutations with a probability of about 2^-128, unless the
+// elements are chosen to collide.
+func IsPermutation(before []int, after []int) bool {
+	return len(before) == len(after) && multisetHash(before) == multisetHash(after)
+}
+
+// unsortedIndex returns the first index whose element is smaller than its
+// predecessor or -1 if the slice is sorted.
+func unsortedIndex(slice []int) int {
+	for i := 1; i < len(slice); i++ {
+		if slice[i] < slice[i-1] {
+			return i
+		}
+	}
+	return -1
+}
+
+// multisetHash returns a hash of the specified elements that does not depend
+// on their order. It adds up two independent hashes of every element, so
+// that the result only depends on which elements occur how often.
+func multisetHash(slice []int) [2]uint64 {
+	var sum [2]uint64
+	for _, element := range slice {
+		sum[0] += mix(uint64(element) + 0x9e3779b97f4a7c15)
+		sum[1] += mix(uint64(element) ^ 0xbf58476d1ce4e5b9)
+	}
+	return sum
+}
+
+// mix is the finalizer of the SplitMix64 generator, which spreads every input
+// bit over the whole result.
+func mix(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
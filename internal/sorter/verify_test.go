@@ -0,0 +1,169 @@
+package sorter
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestIsSorted tests the IsSorted function.
+func TestIsSorted(t *testing.T) {
+	tests := map[string]struct {
+		slice []int
+		want  bool
+	}{
+		"empty_input": {
+			slice: []int{},
+			want:  true,
+		},
+		"one_element": {
+			slice: []int{42},
+			want:  true,
+		},
+		"repeating_elements": {
+			slice: []int{1, 1, 5, 5, 8},
+			want:  true,
+		},
+		"last_element_wrong": {
+			slice: []int{1, 2, 3, 0},
+			want:  false,
+		},
+	}
+	for name, test := range tests {
+		got := IsSorted(test.slice)
+		if got != test.want {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
+
+// TestIsStablySorted tests the IsStablySorted function.
+func TestIsStablySorted(t *testing.T) {
+	tests := map[string]struct {
+		slice   []int
+		indices []int
+		want    bool
+	}{
+		"empty_input": {
+			slice:   []int{},
+			indices: []int{},
+			want:    true,
+		},
+		"stable": {
+			slice:   []int{1, 4, 4, 4, 7},
+			indices: []int{3, 0, 2, 4, 1},
+			want:    true,
+		},
+		"unstable": {
+			slice:   []int{1, 4, 4, 4, 7},
+			indices: []int{3, 0, 4, 2, 1},
+			want:    false,
+		},
+		"unsorted": {
+			slice:   []int{4, 1},
+			indices: []int{0, 1},
+			want:    false,
+		},
+		"length_mismatch": {
+			slice:   []int{1, 2},
+			indices: []int{0},
+			want:    false,
+		},
+	}
+	for name, test := range tests {
+		got := IsStablySorted(test.slice, test.indices)
+		if got != test.want {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
+
+// TestIsPermutation tests the IsPermutation function.
+func TestIsPermutation(t *testing.T) {
+	tests := map[string]struct {
+		before []int
+		after  []int
+		want   bool
+	}{
+		"empty_input": {
+			before: []int{},
+			after:  []int{},
+			want:   true,
+		},
+		"reordered": {
+			before: []int{3, -1, 3, 7},
+			after:  []int{-1, 3, 7, 3},
+			want:   true,
+		},
+		"other_multiplicity": {
+			before: []int{1, 1, 2},
+			after:  []int{1, 2, 2},
+			want:   false,
+		},
+		"same_sum": {
+			before: []int{1, 4},
+			after:  []int{2, 3},
+			want:   false,
+		},
+		"length_mismatch": {
+			before: []int{1, 2},
+			after:  []int{1, 2, 0},
+			want:   false,
+		},
+	}
+	for name, test := range tests {
+		got := IsPermutation(test.before, test.after)
+		if got != test.want {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+}
+
+// TestVerifyingSorter tests that VerifyingSorter reports broken sort
+// functions when the verification is enabled via the environment.
+func TestVerifyingSorter(t *testing.T) {
+	t.Setenv(VerifyEnv, "1")
+	tests := map[string]struct {
+		sortFunction func([]int)
+		wantIndex    int // -1 for a permutation error, -2 for no error
+	}{
+		"correct": {
+			sortFunction: QuickSort,
+			wantIndex:    -2,
+		},
+		"not_sorting": {
+			sortFunction: func(slice []int) {},
+			wantIndex:    1,
+		},
+		"losing_elements": {
+			sortFunction: func(slice []int) {
+				QuickSort(slice)
+				slice[0] = slice[1]
+			},
+			wantIndex: -1,
+		},
+	}
+	for name, test := range tests {
+		err := VerifyingSorter{test.sortFunction}.Sort([]int{5, 1, 4, 2, 3})
+		var verificationError *VerificationError
+		switch {
+		case test.wantIndex == -2 && err != nil:
+			t.Errorf("%s: got error %v but want none", name, err)
+		case test.wantIndex != -2 && !errors.As(err, &verificationError):
+			t.Errorf("%s: got error %v but want a *VerificationError", name, err)
+		case test.wantIndex != -2 && verificationError.Index != test.wantIndex:
+			t.Errorf("%s: got index %v but want %v", name, verificationError.Index, test.wantIndex)
+		}
+	}
+}
+
+// TestVerifyingSorterDisabled tests that VerifyingSorter skips the checks if
+// they are not enabled.
+func TestVerifyingSorterDisabled(t *testing.T) {
+	if debugBuild {
+		t.Skip("checks are always enabled in debug builds")
+	}
+	t.Setenv(VerifyEnv, "")
+	if err := (VerifyingSorter{func(slice []int) {}}).Sort([]int{2, 1}); err != nil {
+		t.Errorf("got error %v but want none", err)
+	}
+}
@@ -0,0 +1,237 @@
+package streaming
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// DefaultAccuracy is the accuracy parameter k of a Sketch that gives rank
+// errors of about one percent.
+const DefaultAccuracy = 200
+
+// minAccuracy is the smallest accuracy parameter that a Sketch accepts.
+const minAccuracy = 8
+
+// capacityRatio is the factor by which the capacity of the compactors shrinks
+// from each level to the one below.
+const capacityRatio = 2.0 / 3.0
+
+// Sketch estimates the quantiles of a stream of values in memory that grows
+// only logarithmically with the length of the stream. It is a KLL sketch, see
+// Karnin, Lang and Liberty, "Optimal Quantile Approximation in Streams",
+// 2016: values are kept in a hierarchy of compactors, and every compactor
+// that is full is sorted and passes every second value on to the next
+// level, where each value stands for twice as many. Sketches of different
+// streams can be merged. A Sketch is safe for concurrent use.
+type Sketch struct {
+	mu         sync.Mutex
+	k          int
+	compactors [][]float64 // the values of level h have the weight 2^h
+	size       int         // the number of values in all compactors
+	maxSize    int         // the sum of the capacities of all compactors
+	count      int         // the number of values added
+	min, max   float64
+}
+
+// NewSketch returns an empty sketch with the specified accuracy parameter k.
+// The rank error of the estimated quantiles shrinks in proportion to 1/k,
+// and the memory grows in proportion to k. Values of k below 8 are raised
+// to 8.
+func NewSketch(k int) *Sketch {
+	s := &Sketch{k: max(k, minAccuracy), min: math.Inf(1), max: math.Inf(-1)}
+	s.grow()
+	return s
+}
+
+// Add adds the specified value to the stream. NaN values are ignored.
+func (s *Sketch) Add(value float64) {
+	if math.IsNaN(value) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.compactors[0] = append(s.compactors[0], value)
+	s.size++
+	s.count++
+	s.min = math.Min(s.min, value)
+	s.max = math.Max(s.max, value)
+	if s.size >= s.maxSize {
+		s.compress()
+	}
+}
+
+// Merge adds the values summarized by the other sketch to this one, as if
+// its stream had been added. Both sketches may be in use by other goroutines
+// meanwhile.
+func (s *Sketch) Merge(other *Sketch) {
+	other.mu.Lock()
+	compactors := make([][]float64, len(other.compactors))
+	for h, compactor := range other.compactors {
+		compactors[h] = append([]float64{}, compactor...)
+	}
+	count, otherMin, otherMax := other.count, other.min, other.max
+	other.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.compactors) < len(compactors) {
+		s.grow()
+	}
+	for h, compactor := range compactors {
+		s.compactors[h] = append(s.compactors[h], compactor...)
+		s.size += len(compactor)
+	}
+	s.count += count
+	s.min = math.Min(s.min, otherMin)
+	s.max = math.Max(s.max, otherMax)
+	for s.size >= s.maxSize {
+		s.compress()
+	}
+}
+
+// Count returns the number of values added, including those of merged
+// sketches.
+func (s *Sketch) Count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+// Quantile returns an estimate of the q-quantile of the stream, with q
+// between 0 and 1. The 0-quantile and the 1-quantile are the exact minimum
+// and maximum. It returns NaN if the stream is empty or q is out of range.
+func (s *Sketch) Quantile(q float64) float64 {
+	return s.Quantiles(q)[0]
+}
+
+// Quantiles returns estimates of the specified quantiles of the stream, see
+// Quantile.
+func (s *Sketch) Quantiles(qs ...float64) []float64 {
+	values, weights, total := s.weightedValues()
+	result := make([]float64, len(qs))
+	for i, q := range qs {
+		switch {
+		case total == 0 || !(0 <= q && q <= 1):
+			result[i] = math.NaN()
+		case q == 0:
+			result[i] = s.Min()
+		case q == 1:
+			result[i] = s.Max()
+		default:
+			rank := q * float64(total)
+			j := sort.Search(len(weights), func(j int) bool { return float64(weights[j]) >= rank })
+			result[i] = values[min(j, len(values)-1)]
+		}
+	}
+	return result
+}
+
+// Rank returns an estimate of the fraction of the values in the stream that
+// are less than or equal to the specified value, or NaN if the stream is
+// empty.
+func (s *Sketch) Rank(value float64) float64 {
+	values, weights, total := s.weightedValues()
+	if total == 0 {
+		return math.NaN()
+	}
+	j := sort.Search(len(values), func(j int) bool { return values[j] > value })
+	if j == 0 {
+		return 0
+	}
+	return float64(weights[j-1]) / float64(total)
+}
+
+// Min returns the smallest value added, or +Inf if the stream is empty.
+func (s *Sketch) Min() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.min
+}
+
+// Max returns the largest value added, or -Inf if the stream is empty.
+func (s *Sketch) Max() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.max
+}
+
+// weightedValues returns the values of all compactors in ascending order with
+// their cumulative weights, and the total weight.
+func (s *Sketch) weightedValues() ([]float64, []int, int) {
+	type weighted struct {
+		value  float64
+		weight int
+	}
+	s.mu.Lock()
+	var items []weighted
+	for h, compactor := range s.compactors {
+		for _, value := range compactor {
+			items = append(items, weighted{value, 1 << h})
+		}
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(items, func(a, b weighted) int {
+		switch {
+		case a.value < b.value:
+			return -1
+		case a.value > b.value:
+			return 1
+		}
+		return 0
+	})
+	values := make([]float64, len(items))
+	weights := make([]int, len(items))
+	total := 0
+	for i, item := range items {
+		total += item.weight
+		values[i] = item.value
+		weights[i] = total
+	}
+	return values, weights, total
+}
+
+// grow adds a compactor level and recomputes the capacities.
+func (s *Sketch) grow() {
+	s.compactors = append(s.compactors, nil)
+	s.maxSize = 0
+	for h := range s.compactors {
+		s.maxSize += s.capacity(h)
+	}
+}
+
+// capacity returns the number of values that the compactor of the specified
+// level holds before it is compacted. The top level has capacity k, and the
+// levels below have geometrically smaller capacities.
+func (s *Sketch) capacity(h int) int {
+	height := len(s.compactors) - h - 1
+	return int(math.Ceil(math.Pow(capacityRatio, float64(height))*float64(s.k))) + 1
+}
+
+// compress compacts the lowest compactor that is full. It sorts the values
+// and passes either the values at the even or at the odd positions on to the
+// next level, chosen at random so that the errors cancel out on average.
+func (s *Sketch) compress() {
+	for h := 0; h < len(s.compactors); h++ {
+		if len(s.compactors[h]) < s.capacity(h) {
+			continue
+		}
+		if h+1 == len(s.compactors) {
+			s.grow()
+		}
+		compactor := s.compactors[h]
+		slices.Sort(compactor)
+		offset := rand.Intn(2)
+		pairs := len(compactor) / 2
+		for i := 0; i < pairs; i++ {
+			s.compactors[h+1] = append(s.compactors[h+1], compactor[2*i+offset])
+		}
+		// An odd value out stays on this level.
+		s.compactors[h] = append(compactor[:0], compactor[2*pairs:]...)
+		s.size -= pairs
+		return
+	}
+}
@@ -0,0 +1,204 @@
+package streaming
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"gitlab.com/dirk.krummacker/sorter/internal/gsorter"
+)
+
+// streamDistributions are generators of streams of values.
+var streamDistributions = map[string]func(size int) []float64{
+	"uniform": func(size int) []float64 {
+		return generate(size, func(int) float64 { return rand.Float64() })
+	},
+	"normal": func(size int) []float64 {
+		return generate(size, func(int) float64 { return rand.NormFloat64()*10 + 100 })
+	},
+	"exponential": func(size int) []float64 {
+		return generate(size, func(int) float64 { return rand.ExpFloat64() })
+	},
+	"sorted": func(size int) []float64 {
+		return generate(size, func(i int) float64 { return float64(i) })
+	},
+	"reversed": func(size int) []float64 {
+		return generate(size, func(i int) float64 { return float64(size - i) })
+	},
+	"duplicates": func(size int) []float64 {
+		return generate(size, func(int) float64 { return float64(rand.Intn(1000)) })
+	},
+}
+
+// generate returns a slice of the specified size with the values of the
+// specified function of the index.
+func generate(size int, value func(i int) float64) []float64 {
+	result := make([]float64, size)
+	for i := range result {
+		result[i] = value(i)
+	}
+	return result
+}
+
+// exactlySorted returns a sorted copy of the specified values. The copy is
+// shuffled first, because QuickSort is slow on reversed input.
+func exactlySorted(values []float64) []float64 {
+	result := append([]float64{}, values...)
+	rand.Shuffle(len(result), func(i, j int) { result[i], result[j] = result[j], result[i] })
+	gsorter.QuickSort(gsorter.Float64Sortable(result))
+	return result
+}
+
+// rankError returns how far the rank of the specified value in the sorted
+// values is from the specified quantile. Equal values share the range of
+// ranks between their first and last position.
+func rankError(sorted []float64, q float64, value float64) float64 {
+	lower := float64(sort.SearchFloat64s(sorted, value)) / float64(len(sorted))
+	upper := float64(sort.Search(len(sorted), func(i int) bool { return sorted[i] > value })) /
+		float64(len(sorted))
+	switch {
+	case q < lower:
+		return lower - q
+	case q > upper:
+		return q - upper
+	}
+	return 0
+}
+
+// checkAccuracy checks that the quantiles that the sketch estimates are
+// within the specified rank error of the exact ones.
+func checkAccuracy(t *testing.T, name string, sketch *Sketch, sorted []float64, maxError float64) {
+	t.Helper()
+	if sketch.Count() != len(sorted) {
+		t.Errorf("%s: got count %d but want %d", name, sketch.Count(), len(sorted))
+	}
+	for q := 0.0; q <= 1; q += 0.01 {
+		estimate := sketch.Quantile(q)
+		if e := rankError(sorted, q, estimate); e > maxError {
+			t.Errorf("%s: quantile %.2f estimate %v has rank error %.4f", name, q, estimate, e)
+		}
+		exact := sorted[max(int(math.Ceil(q*float64(len(sorted))))-1, 0)]
+		if e := math.Abs(sketch.Rank(exact) - rankOf(sorted, exact)); e > maxError {
+			t.Errorf("%s: rank of %v has error %.4f", name, exact, e)
+		}
+	}
+	if sketch.Min() != sorted[0] || sketch.Max() != sorted[len(sorted)-1] {
+		t.Errorf("%s: got min %v and max %v but want %v and %v", name, sketch.Min(), sketch.Max(),
+			sorted[0], sorted[len(sorted)-1])
+	}
+}
+
+// rankOf returns the exact fraction of the sorted values that are less than
+// or equal to the specified value.
+func rankOf(sorted []float64, value float64) float64 {
+	return float64(sort.Search(len(sorted), func(i int) bool { return sorted[i] > value })) /
+		float64(len(sorted))
+}
+
+// TestSketchAccuracy tests the estimated quantiles of all distributions and
+// several accuracy parameters against an exact computation.
+func TestSketchAccuracy(t *testing.T) {
+	tests := map[string]struct {
+		k        int
+		maxError float64
+	}{
+		"k=50":  {50, 0.06},
+		"k=200": {DefaultAccuracy, 0.02},
+		"k=800": {800, 0.006},
+	}
+	for distributionName, distribution := range streamDistributions {
+		values := distribution(100000)
+		sorted := exactlySorted(values)
+		for name, test := range tests {
+			sketch := NewSketch(test.k)
+			for _, value := range values {
+				sketch.Add(value)
+			}
+			checkAccuracy(t, distributionName+"/"+name, sketch, sorted, test.maxError)
+		}
+	}
+}
+
+// TestSketchConcurrentMerge tests that sketches can be filled and merged from
+// several goroutines at once without losing accuracy.
+func TestSketchConcurrentMerge(t *testing.T) {
+	const goroutines, size = 8, 20000
+	values := streamDistributions["normal"](goroutines * size)
+	merged := NewSketch(DefaultAccuracy)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(part []float64) {
+			defer wg.Done()
+			if len(part)%2 == 0 {
+				// Adds half of the values directly to the merged sketch.
+				for _, value := range part[:len(part)/2] {
+					merged.Add(value)
+				}
+				part = part[len(part)/2:]
+			}
+			sketch := NewSketch(DefaultAccuracy)
+			for i, value := range part {
+				sketch.Add(value)
+				if i%5000 == 4999 {
+					merged.Merge(sketch)
+					sketch = NewSketch(DefaultAccuracy)
+				}
+			}
+			merged.Merge(sketch)
+		}(values[g*size : (g+1)*size])
+	}
+	wg.Wait()
+	checkAccuracy(t, "merged", merged, exactlySorted(values), 0.02)
+}
+
+// TestSketchEdgeCases tests empty sketches, single values, NaNs and invalid
+// quantiles.
+func TestSketchEdgeCases(t *testing.T) {
+	sketch := NewSketch(0)
+	if got := sketch.Quantile(0.5); !math.IsNaN(got) {
+		t.Errorf("empty: got %v but want NaN", got)
+	}
+	if got := sketch.Rank(1); !math.IsNaN(got) {
+		t.Errorf("empty rank: got %v but want NaN", got)
+	}
+	sketch.Add(math.NaN())
+	sketch.Add(42)
+	tests := map[string]struct {
+		q    float64
+		want float64
+	}{
+		"min":    {0, 42},
+		"median": {0.5, 42},
+		"max":    {1, 42},
+	}
+	for name, test := range tests {
+		if got := sketch.Quantile(test.q); got != test.want {
+			t.Errorf("%s: got %v but want %v", name, got, test.want)
+		}
+	}
+	for _, q := range []float64{-0.1, 1.1, math.NaN()} {
+		if got := sketch.Quantile(q); !math.IsNaN(got) {
+			t.Errorf("q=%v: got %v but want NaN", q, got)
+		}
+	}
+	if got := sketch.Count(); got != 1 {
+		t.Errorf("count: got %v but want 1", got)
+	}
+	if got := fmt.Sprint(sketch.Rank(41), sketch.Rank(42)); got != "0 1" {
+		t.Errorf("rank: got %v but want 0 1", got)
+	}
+}
+
+// BenchmarkSketchAdd measures adding values to a sketch.
+func BenchmarkSketchAdd(b *testing.B) {
+	values := streamDistributions["uniform"](1 << 16)
+	sketch := NewSketch(DefaultAccuracy)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sketch.Add(values[i&(1<<16-1)])
+	}
+}
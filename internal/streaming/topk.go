@@ -0,0 +1,115 @@
+package streaming
+
+import (
+	"cmp"
+	"sync"
+
+	"gitlab.com/dirk.krummacker/sorter/internal/gsorter"
+)
+
+// TopK keeps the k largest values of a stream in a bounded heap, so that its
+// memory does not grow with the stream. It is safe for concurrent use.
+type TopK[T any] struct {
+	mu         sync.Mutex
+	k          int
+	comparator gsorter.Comparator[T]
+	heap       []T // the smallest kept value is at the root
+}
+
+// NewTopK returns an empty accumulator for the k largest values. A negative
+// k is treated as 0.
+func NewTopK[T cmp.Ordered](k int) *TopK[T] {
+	return NewTopKFunc(k, cmp.Compare[T])
+}
+
+// NewTopKFunc returns an empty accumulator for the k largest values in the
+// order of the specified comparator. A negative k is treated as 0.
+func NewTopKFunc[T any](k int, comparator gsorter.Comparator[T]) *TopK[T] {
+	k = max(k, 0)
+	return &TopK[T]{k: k, comparator: comparator, heap: make([]T, 0, k)}
+}
+
+// Add adds the specified value to the stream. It takes O(log k) time.
+func (t *TopK[T]) Add(value T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.add(value)
+}
+
+// add adds the specified value while the lock is held.
+func (t *TopK[T]) add(value T) {
+	switch {
+	case len(t.heap) < t.k:
+		t.heap = append(t.heap, value)
+		t.siftUp(len(t.heap) - 1)
+	case t.k > 0 && t.comparator.Compare(value, t.heap[0]) > 0:
+		t.heap[0] = value
+		t.siftDown(t.heap, 0)
+	}
+}
+
+// Merge adds the values kept by the other accumulator to this one, as if
+// its stream had been added. Both accumulators may be in use by other
+// goroutines meanwhile.
+func (t *TopK[T]) Merge(other *TopK[T]) {
+	values := other.Values()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, value := range values {
+		t.add(value)
+	}
+}
+
+// Len returns the number of values kept, which is at most k.
+func (t *TopK[T]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.heap)
+}
+
+// Values returns a new slice with the k largest values added so far, in
+// descending order.
+func (t *TopK[T]) Values() []T {
+	t.mu.Lock()
+	result := append([]T{}, t.heap...)
+	t.mu.Unlock()
+
+	// Sorts the copy of the heap by moving the root to the end repeatedly.
+	for end := len(result) - 1; end > 0; end-- {
+		result[0], result[end] = result[end], result[0]
+		t.siftDown(result[:end], 0)
+	}
+	return result
+}
+
+// siftUp moves the value at the specified index up until its parent is not
+// greater.
+func (t *TopK[T]) siftUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if t.comparator.Compare(t.heap[i], t.heap[parent]) >= 0 {
+			return
+		}
+		t.heap[i], t.heap[parent] = t.heap[parent], t.heap[i]
+		i = parent
+	}
+}
+
+// siftDown moves the value at the specified index of the heap down until
+// none of its children is smaller.
+func (t *TopK[T]) siftDown(heap []T, i int) {
+	for {
+		child := 2*i + 1
+		if child >= len(heap) {
+			return
+		}
+		if child+1 < len(heap) && t.comparator.Compare(heap[child+1], heap[child]) < 0 {
+			child++
+		}
+		if t.comparator.Compare(heap[child], heap[i]) >= 0 {
+			return
+		}
+		heap[i], heap[child] = heap[child], heap[i]
+		i = child
+	}
+}
@@ -0,0 +1,93 @@
+package streaming
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"gitlab.com/dirk.krummacker/sorter/internal/gsorter"
+	"gitlab.com/dirk.krummacker/sorter/internal/sorter"
+)
+
+// exactTopK returns the k largest of the specified values in descending
+// order, computed by sorting all of them with QuickSort.
+func exactTopK(values []int, k int) []int {
+	sorted := append([]int{}, values...)
+	sorter.QuickSort(sorted)
+	result := []int{}
+	for i := len(sorted) - 1; i >= 0 && len(result) < k; i-- {
+		result = append(result, sorted[i])
+	}
+	return result
+}
+
+// TestTopK tests the accumulator against an exact computation.
+func TestTopK(t *testing.T) {
+	tests := map[string]struct {
+		size int
+		k    int
+	}{
+		"k_zero":        {1000, 0},
+		"k_one":         {1000, 1},
+		"k_small":       {100000, 10},
+		"k_large":       {100000, 5000},
+		"short_stream":  {5, 10},
+		"empty_stream":  {0, 10},
+		"k_equals_size": {100, 100},
+	}
+	for name, test := range tests {
+		values := sorter.CreateRandomInts(test.size)
+		topK := NewTopK[int](test.k)
+		for _, value := range values {
+			topK.Add(value)
+		}
+		want := exactTopK(values, test.k)
+		if got := topK.Values(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v but want %v", name, got, want)
+		}
+		if topK.Len() != len(want) {
+			t.Errorf("%s: got length %d but want %d", name, topK.Len(), len(want))
+		}
+	}
+}
+
+// TestTopKFunc tests the accumulator with a comparator.
+func TestTopKFunc(t *testing.T) {
+	topK := NewTopKFunc(3, gsorter.By(func(s string) int { return len(s) }).
+		Then(gsorter.By(func(s string) string { return s })))
+	for _, word := range strings.Fields("the quick brown fox jumps over the lazy dog") {
+		topK.Add(word)
+	}
+	if got, want := topK.Values(), []string{"quick", "jumps", "brown"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q but want %q", got, want)
+	}
+}
+
+// TestTopKConcurrentMerge tests that accumulators can be filled and merged
+// from several goroutines at once.
+func TestTopKConcurrentMerge(t *testing.T) {
+	const goroutines, size, k = 8, 10000, 100
+	values := sorter.CreateRandomInts(goroutines * size)
+	merged := NewTopK[int](k)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(part []int) {
+			defer wg.Done()
+			local := NewTopK[int](k)
+			for i, value := range part {
+				if i%2 == 0 {
+					merged.Add(value)
+				} else {
+					local.Add(value)
+				}
+			}
+			merged.Merge(local)
+		}(values[g*size : (g+1)*size])
+	}
+	wg.Wait()
+	if got, want := merged.Values(), exactTopK(values, k); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v but want %v", got, want)
+	}
+}